@@ -3,8 +3,14 @@ module github.com/saitho/terraform-provider-podman
 go 1.14
 
 require (
+	github.com/blang/semver v3.5.1+incompatible
+	github.com/containers/common v0.22.0
 	github.com/containers/image/v5 v5.6.0
 	github.com/containers/podman/v2 v2.1.1
 	github.com/hashicorp/go-cty v1.4.1-0.20200414143053-d3edf31b6320
 	github.com/hashicorp/terraform-plugin-sdk/v2 v2.0.4
+	github.com/modern-go/reflect2 v1.0.2 // indirect
+	github.com/opencontainers/go-digest v1.0.0
+	github.com/opencontainers/image-spec v1.0.2-0.20190823105129-775207bd45b6
+	github.com/opencontainers/runtime-spec v1.0.3-0.20200817204227-f9c09b4ea1df
 )
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+)
+
+var errCallCancelled = errors.New("call was cancelled")
+
+// connTracker remembers the open connections of a call so they can be closed on cancellation
+type connTracker struct {
+	mu     sync.Mutex
+	conns  map[*trackedConn]struct{}
+	closed bool
+}
+
+type trackedConn struct {
+	net.Conn
+	tracker *connTracker
+}
+
+func (c *trackedConn) Close() error {
+	c.tracker.mu.Lock()
+	delete(c.tracker.conns, c)
+	c.tracker.mu.Unlock()
+	return c.Conn.Close()
+}
+
+func (t *connTracker) track(conn net.Conn) (net.Conn, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		// Some dialers of the bindings ignore the context, so late connections are refused here
+		conn.Close()
+		return nil, errCallCancelled
+	}
+	tracked := &trackedConn{Conn: conn, tracker: t}
+	t.conns[tracked] = struct{}{}
+	return tracked, nil
+}
+
+func (t *connTracker) closeAll() {
+	t.mu.Lock()
+	conns := t.conns
+	t.conns = map[*trackedConn]struct{}{}
+	t.closed = true
+	t.mu.Unlock()
+	for conn := range conns {
+		conn.Conn.Close()
+	}
+}
+
+// call runs fn with the connection context of the client, its requests bound to ctx.
+// Each call gets its own transport and in-flight requests are interrupted by closing
+// its connections once ctx is done. The error of fn is replaced by ctx.Err() in that case.
+func (c *Client) call(ctx context.Context, fn func(connCtx context.Context) error) error {
+	if c.transport == nil {
+		return errors.New("client is not connected")
+	}
+	c.transport.calls.Lock()
+	defer c.transport.calls.Unlock()
+
+	base := c.transport.base
+	tracker := &connTracker{conns: map[*trackedConn]struct{}{}}
+	callTransport := base.Clone()
+	callTransport.DialContext = func(_ context.Context, network, addr string) (net.Conn, error) {
+		c, err := base.DialContext(ctx, network, addr)
+		if err != nil {
+			return nil, err
+		}
+		return tracker.track(c)
+	}
+	defer callTransport.CloseIdleConnections()
+
+	// Installed even without retries, it keeps the bindings from sending failed requests again
+	c.transport.setCurrent(&retryTransport{base: callTransport, config: c.config.Retry, ctx: ctx})
+	defer c.transport.setCurrent(nil)
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			tracker.closeAll()
+		case <-done:
+		}
+	}()
+
+	err := fn(c.context)
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return err
+}
@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v2/pkg/bindings"
+)
+
+func TestCallCancellation(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "cancel")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	listener, err := net.Listen("unix", filepath.Join(tmp, "podman.sock"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	release := make(chan struct{})
+	defer close(release)
+	go http.Serve(listener, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/images/pull") {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		// Simulate a stuck pull
+		<-release
+	}))
+
+	connCtx, err := bindings.NewConnection(context.Background(), "unix://"+listener.Addr().String())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	c := &Client{}
+	if err := c.useConnection(connCtx); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	stuckPull := func(connCtx context.Context) error {
+		conn, err := bindings.GetClient(connCtx)
+		if err != nil {
+			return err
+		}
+		_, err = conn.DoRequest(nil, http.MethodPost, "/images/pull", nil, nil)
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- c.call(ctx, stuckPull)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	select {
+	case err := <-done:
+		if err != context.Canceled {
+			t.Fatalf("expected %s, got %v", context.Canceled, err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("request was not cancelled")
+	}
+
+	// Calls with another context are not affected by the cancelled one
+	if err := c.call(context.Background(), func(connCtx context.Context) error {
+		conn, err := bindings.GetClient(connCtx)
+		if err != nil {
+			return err
+		}
+		_, err = conn.DoRequest(nil, http.MethodGet, "/info", nil, nil)
+		return err
+	}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if err := c.call(ctx, stuckPull); err != context.DeadlineExceeded {
+		t.Fatalf("expected %s, got %v", context.DeadlineExceeded, err)
+	}
+}
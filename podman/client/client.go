@@ -2,8 +2,12 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"os"
+	"strings"
 
+	"github.com/blang/semver"
+	"github.com/containers/common/pkg/config"
 	"github.com/containers/podman/v2/libpod/define"
 	"github.com/containers/podman/v2/pkg/bindings"
 	"github.com/containers/podman/v2/pkg/bindings/containers"
@@ -13,61 +17,137 @@ import (
 )
 
 type Client struct {
-	context context.Context
+	context   context.Context
+	transport *callTransport
+	version   semver.Version
+	config    Config
+}
+
+func NewClient(config Config) *Client {
+	return &Client{config: config}
 }
 
 func (c *Client) Connect() error {
-	// Get Podman socket location
-	sockDir := os.Getenv("XDG_RUNTIME_DIR")
-	socket := "unix:" + sockDir + "/podman/podman.sock"
+	return c.ConnectContext(context.Background())
+}
 
-	// Connect to Podman socket
-	connText, err := bindings.NewConnection(context.Background(), socket)
-	if err != nil {
-		return err
+// ConnectContext connects to the Podman service, giving up once ctx is done.
+// Later calls take a context of their own.
+func (c *Client) ConnectContext(ctx context.Context) error {
+	// Find the Podman service the same way the Podman CLI does
+	engine := &config.EngineConfig{}
+	if conf, err := config.ReadCustomConfig(); err == nil {
+		engine = &conf.Engine
+	} else if c.config.Connection != "" {
+		return fmt.Errorf("Unable to read Podman connections from containers.conf: %s", err)
+	}
+	ep, diags := discoverEndpoint(c.config.Connection, engine, os.LookupEnv, os.Geteuid())
+	if diags.HasError() {
+		return &ConnectionError{Diagnostics: diags}
+	}
+	if socketPath, ok := unixSocketPath(ep.URI); ok {
+		if diags := socketPreflight(socketPath); diags.HasError() {
+			return &ConnectionError{Diagnostics: diags}
+		}
 	}
-	c.context = connText
-	return nil
-}
 
-func (c *Client) PullImage(rawImage string) error {
-	_, err := images.Pull(c.context, rawImage, entities.ImagePullOptions{})
-	if err != nil {
+	// Connect to Podman socket, TLS settings only apply to tcp:// endpoints
+	var connText context.Context
+	if c.config.TLS != nil && strings.HasPrefix(ep.URI, "tcp://") {
+		tlsConfig, err := c.config.TLS.tlsConfig()
+		if err != nil {
+			return err
+		}
+		connText, err = newTLSConnection(ctx, ep.URI, tlsConfig)
+		if err != nil {
+			return err
+		}
+	} else {
+		var err error
+		connText, err = bindings.NewConnectionWithIdentity(ctx, ep.URI, ep.Identity)
+		if err != nil {
+			return err
+		}
+	}
+	if err := c.useConnection(connText); err != nil {
 		return err
 	}
-	return nil
+	return c.queryVersion(ctx)
+}
+
+func (c *Client) PullImage(ctx context.Context, rawImage string) error {
+	return c.call(ctx, func(connCtx context.Context) error {
+		return c.retryCall(ctx, isTransientPullError, func() error {
+			_, err := images.Pull(connCtx, rawImage, entities.ImagePullOptions{})
+			return err
+		})
+	})
 }
 
-func (c *Client) CreateContainer(s *specgen.SpecGenerator) (string, error) {
+func (c *Client) CreateContainer(ctx context.Context, s *specgen.SpecGenerator) (string, error) {
 	s.Terminal = true
-	r, err := containers.CreateWithSpec(c.context, s)
-	if err != nil {
-		return "", err
-	}
-	return r.ID, c.StartContainer(r.ID)
+	var id string
+	err := c.call(ctx, func(connCtx context.Context) error {
+		r, err := containers.CreateWithSpec(connCtx, s)
+		if err != nil {
+			return err
+		}
+		id = r.ID
+		return nil
+	})
+	return id, err
 }
 
-func (c *Client) StartContainer(containerId string) error {
-	err := containers.Start(c.context, containerId, nil)
+func (c *Client) StartContainer(ctx context.Context, containerId string) error {
+	err := c.call(ctx, func(connCtx context.Context) error {
+		return c.retryCall(ctx, isImproperStateError, func() error {
+			return containers.Start(connCtx, containerId, nil)
+		})
+	})
 	if err != nil {
 		return err
 	}
-	return c.WaitContainer(containerId)
+	return c.WaitContainer(ctx, containerId)
 }
 
-func (c *Client) WaitContainer(containerId string) error {
+func (c *Client) WaitContainer(ctx context.Context, containerId string) error {
 	running := define.ContainerStateRunning
-	_, err := containers.Wait(c.context, containerId, &running)
-	if err != nil {
+	return c.call(ctx, func(connCtx context.Context) error {
+		_, err := containers.Wait(connCtx, containerId, &running)
 		return err
-	}
-	return nil
+	})
 }
 
-func (c *Client) StopContainer(containerId string) error {
-	return containers.Stop(c.context, containerId, nil)
+func (c *Client) StopContainer(ctx context.Context, containerId string) error {
+	return c.call(ctx, func(connCtx context.Context) error {
+		return c.retryCall(ctx, isImproperStateError, func() error {
+			return containers.Stop(connCtx, containerId, nil)
+		})
+	})
 }
 
-func (c *Client) RemoveContainer(containerId string) error {
-	return containers.Remove(c.context, containerId, newTrue(), newTrue())
+func (c *Client) RemoveContainer(ctx context.Context, containerId string) error {
+	return c.call(ctx, func(connCtx context.Context) error {
+		return c.retryCall(ctx, isImproperStateError, func() error {
+			return containers.Remove(connCtx, containerId, newTrue(), newTrue())
+		})
+	})
+}
+
+func (c *Client) ListContainers(ctx context.Context, filters map[string][]string, all bool) ([]entities.ListContainer, error) {
+	var list []entities.ListContainer
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		list, err = containers.List(connCtx, filters, &all, nil, nil, nil)
+		return err
+	})
+	return list, err
+}
+
+func (c *Client) InspectContainer(ctx context.Context, nameOrId string) (*define.InspectContainerData, error) {
+	var data *define.InspectContainerData
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		data, err = containers.Inspect(connCtx, nameOrId, nil)
+		return err
+	})
+	return data, err
 }
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/containers/podman/v2/pkg/bindings"
+)
+
+// CopyToContainer extracts a tar archive into the given directory of a container.
+func (c *Client) CopyToContainer(ctx context.Context, containerId, path string, archive io.Reader) error {
+	// The v2 bindings have no archive support, so the endpoint is called directly.
+	// Older services answer with 404 or 501 depending on the version, so the version is checked up front
+	if err := c.RequireFeature(FeatureArchive); err != nil {
+		return err
+	}
+	params := url.Values{}
+	params.Set("path", path)
+	return c.call(ctx, func(connCtx context.Context) error {
+		conn, err := bindings.GetClient(connCtx)
+		if err != nil {
+			return err
+		}
+		response, err := conn.DoRequest(archive, http.MethodPut, "/containers/%s/archive", params, nil, containerId)
+		if err != nil {
+			return err
+		}
+		return response.Process(nil)
+	})
+}
+
+// CopyFromContainer returns a tar archive of the given file or directory of a container.
+func (c *Client) CopyFromContainer(ctx context.Context, containerId, path string) ([]byte, error) {
+	if err := c.RequireFeature(FeatureArchive); err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("path", path)
+	var archive []byte
+	err := c.call(ctx, func(connCtx context.Context) error {
+		conn, err := bindings.GetClient(connCtx)
+		if err != nil {
+			return err
+		}
+		response, err := conn.DoRequest(nil, http.MethodGet, "/containers/%s/archive", params, nil, containerId)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+
+		if !response.IsSuccess() {
+			return response.Process(nil)
+		}
+		archive, err = ioutil.ReadAll(response.Body)
+		return err
+	})
+	return archive, err
+}
@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/api/handlers"
+	"github.com/containers/podman/v2/pkg/bindings/containers"
+)
+
+type ExecResult struct {
+	SessionId string
+	Stdout    string
+	Stderr    string
+	ExitCode  int
+}
+
+// ExecContainer runs a command in a running container and waits for it to finish.
+func (c *Client) ExecContainer(ctx context.Context, containerId string, config *handlers.ExecCreateConfig) (*ExecResult, error) {
+	config.AttachStdout = true
+	config.AttachStderr = true
+	config.Tty = false
+
+	var result *ExecResult
+	err := c.call(ctx, func(connCtx context.Context) error {
+		sessionId, err := containers.ExecCreate(connCtx, containerId, config)
+		if err != nil {
+			return err
+		}
+
+		stdout := &bufferCloser{}
+		stderr := &bufferCloser{}
+		streams := &define.AttachStreams{
+			OutputStream: stdout,
+			ErrorStream:  stderr,
+			AttachOutput: true,
+			AttachError:  true,
+		}
+		if err := containers.ExecStartAndAttach(connCtx, sessionId, streams); err != nil {
+			return err
+		}
+
+		session, err := containers.ExecInspect(connCtx, sessionId)
+		if err != nil {
+			return err
+		}
+
+		result = &ExecResult{
+			SessionId: sessionId,
+			Stdout:    stdout.String(),
+			Stderr:    stderr.String(),
+			ExitCode:  session.ExitCode,
+		}
+		return nil
+	})
+	return result, err
+}
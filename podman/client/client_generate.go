@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+
+	"github.com/containers/podman/v2/pkg/bindings/generate"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+// GenerateKube returns the Kubernetes YAML for a container or pod.
+func (c *Client) GenerateKube(ctx context.Context, nameOrId string, options entities.GenerateKubeOptions) (string, error) {
+	var content []byte
+	err := c.call(ctx, func(connCtx context.Context) error {
+		report, err := generate.Kube(connCtx, nameOrId, options)
+		if err != nil {
+			return err
+		}
+		if closer, ok := report.Reader.(io.Closer); ok {
+			defer closer.Close()
+		}
+
+		content, err = ioutil.ReadAll(report.Reader)
+		return err
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(content), nil
+}
+
+// GenerateSystemd returns the systemd units for a container or pod, keyed by unit name.
+func (c *Client) GenerateSystemd(ctx context.Context, nameOrId string, options entities.GenerateSystemdOptions) (map[string]string, error) {
+	if err := c.RequireFeature(FeatureGenerateSystemd); err != nil {
+		return nil, err
+	}
+	var units map[string]string
+	err := c.call(ctx, func(connCtx context.Context) error {
+		report, err := generate.Systemd(connCtx, nameOrId, options)
+		if err != nil {
+			return err
+		}
+		units = report.Units
+		return nil
+	})
+	return units, err
+}
@@ -1,6 +1,28 @@
 package client
 
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/containers/podman/v2/pkg/bindings"
+)
+
 func newTrue() *bool {
 	b := true
 	return &b
 }
+
+// bufferCloser is a bytes.Buffer satisfying io.WriteCloser for attach streams
+type bufferCloser struct {
+	bytes.Buffer
+}
+
+func (b *bufferCloser) Close() error {
+	return nil
+}
+
+// IsNotFound reports whether err is an API error for an object that does not exist.
+func IsNotFound(err error) bool {
+	code, checkErr := bindings.CheckResponseCode(err)
+	return checkErr == nil && code == http.StatusNotFound
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"io"
+
+	"github.com/containers/podman/v2/pkg/bindings/containers"
+	"github.com/containers/podman/v2/pkg/bindings/images"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+func (c *Client) InspectImage(ctx context.Context, nameOrId string) (*entities.ImageInspectReport, error) {
+	var report *entities.ImageInspectReport
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		report, err = images.GetImage(connCtx, nameOrId, nil)
+		return err
+	})
+	return report, err
+}
+
+func (c *Client) ListImages(ctx context.Context, filters map[string][]string, all bool) ([]*entities.ImageSummary, error) {
+	var list []*entities.ImageSummary
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		list, err = images.List(connCtx, &all, filters)
+		return err
+	})
+	return list, err
+}
+
+func (c *Client) RemoveImage(ctx context.Context, nameOrId string) error {
+	return c.call(ctx, func(connCtx context.Context) error {
+		_, err := images.Remove(connCtx, nameOrId, false)
+		return err
+	})
+}
+
+// SaveImages writes the given images as a docker-archive or oci-archive tarball to w.
+func (c *Client) SaveImages(ctx context.Context, namesOrIds []string, format string, w io.Writer) error {
+	return c.call(ctx, func(connCtx context.Context) error {
+		if len(namesOrIds) == 1 {
+			return images.Export(connCtx, namesOrIds[0], w, &format, nil)
+		}
+		return images.MultiExport(connCtx, namesOrIds, w, &format, nil)
+	})
+}
+
+// LoadImages loads a docker-archive or oci-archive tarball and returns the names of the loaded images.
+func (c *Client) LoadImages(ctx context.Context, r io.Reader) ([]string, error) {
+	var names []string
+	err := c.call(ctx, func(connCtx context.Context) error {
+		report, err := images.Load(connCtx, r, nil)
+		if err != nil {
+			return err
+		}
+		names = report.Names
+		return nil
+	})
+	return names, err
+}
+
+// CommitContainer creates an image from the filesystem of a container and returns its ID.
+func (c *Client) CommitContainer(ctx context.Context, nameOrId string, options containers.CommitOptions) (string, error) {
+	if len(options.Changes) > 0 {
+		if err := c.RequireFeature(FeatureCommitChanges); err != nil {
+			return "", err
+		}
+	}
+	var id string
+	err := c.call(ctx, func(connCtx context.Context) error {
+		r, err := containers.Commit(connCtx, nameOrId, options)
+		if err != nil {
+			return err
+		}
+		id = r.ID
+		return nil
+	})
+	return id, err
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v2/libpod/image"
+	"github.com/containers/podman/v2/pkg/auth"
+	"github.com/containers/podman/v2/pkg/bindings"
+	"github.com/containers/podman/v2/pkg/bindings/images"
+	"github.com/containers/podman/v2/pkg/bindings/manifests"
+	"github.com/opencontainers/go-digest"
+)
+
+func (c *Client) CreateManifest(ctx context.Context, name string) (string, error) {
+	var id string
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		id, err = manifests.Create(connCtx, []string{name}, nil, nil)
+		return err
+	})
+	return id, err
+}
+
+func (c *Client) AddToManifest(ctx context.Context, name string, options image.ManifestAddOpts) error {
+	return c.call(ctx, func(connCtx context.Context) error {
+		_, err := manifests.Add(connCtx, name, options)
+		return err
+	})
+}
+
+func (c *Client) InspectManifest(ctx context.Context, name string) (*manifest.Schema2List, error) {
+	var list *manifest.Schema2List
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		list, err = manifests.Inspect(connCtx, name)
+		return err
+	})
+	return list, err
+}
+
+// ManifestDigest returns the digest of the manifest list as stored by the service
+func (c *Client) ManifestDigest(ctx context.Context, name string) (digest.Digest, error) {
+	var listDigest digest.Digest
+	err := c.call(ctx, func(connCtx context.Context) error {
+		conn, err := bindings.GetClient(connCtx)
+		if err != nil {
+			return err
+		}
+		response, err := conn.DoRequest(nil, http.MethodGet, "/manifests/%s/json", nil, nil, name)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		if !response.IsSuccess() {
+			return response.Process(nil)
+		}
+		raw, err := ioutil.ReadAll(response.Body)
+		if err != nil {
+			return err
+		}
+		listDigest = digest.FromBytes(raw)
+		return nil
+	})
+	return listDigest, err
+}
+
+// PushManifest pushes the manifest list and its images. Credentials, if any,
+// are sent in the X-Registry-Auth header.
+func (c *Client) PushManifest(ctx context.Context, name, destination string, registryAuth *types.DockerAuthConfig) error {
+	var header map[string]string
+	if registryAuth != nil {
+		if err := c.RequireFeature(FeatureManifestPushAuth); err != nil {
+			return err
+		}
+		var err error
+		if header, err = auth.Header(nil, "", registryAuth.Username, registryAuth.Password); err != nil {
+			return err
+		}
+	}
+	params := url.Values{}
+	params.Set("image", name)
+	params.Set("destination", destination)
+	params.Set("all", "true")
+
+	// manifests.Push does not check the response status, so the request is made here
+	return c.call(ctx, func(connCtx context.Context) error {
+		conn, err := bindings.GetClient(connCtx)
+		if err != nil {
+			return err
+		}
+		response, err := conn.DoRequest(nil, http.MethodPost, "/manifests/%s/push", params, header, name)
+		if err != nil {
+			return err
+		}
+		return response.Process(nil)
+	})
+}
+
+func (c *Client) RemoveManifest(ctx context.Context, name string) error {
+	return c.call(ctx, func(connCtx context.Context) error {
+		_, err := images.Remove(connCtx, name, false)
+		return err
+	})
+}
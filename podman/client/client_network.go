@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/podman/v2/pkg/bindings/network"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+func (c *Client) InspectNetwork(ctx context.Context, name string) (entities.NetworkInspectReport, error) {
+	var reports []entities.NetworkInspectReport
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		reports, err = network.Inspect(connCtx, name)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("network %s not found", name)
+	}
+	return reports[0], nil
+}
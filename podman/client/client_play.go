@@ -0,0 +1,40 @@
+package client
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+
+	"github.com/containers/podman/v2/pkg/bindings/play"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+// PlayKube creates the pods and containers described by the given Kubernetes YAML.
+func (c *Client) PlayKube(ctx context.Context, content []byte, options entities.PlayKubeOptions) (*entities.PlayKubeReport, error) {
+	if options.Network != "" {
+		if err := c.RequireFeature(FeaturePlayKubeNetwork); err != nil {
+			return nil, err
+		}
+	}
+	// The bindings only accept a file path, so the YAML is staged in a temporary file
+	f, err := ioutil.TempFile("", "terraform-podman-kube-*.yaml")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	var report *entities.PlayKubeReport
+	err = c.call(ctx, func(connCtx context.Context) (err error) {
+		report, err = play.Kube(connCtx, f.Name(), options)
+		return err
+	})
+	return report, err
+}
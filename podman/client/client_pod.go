@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+
+	"github.com/containers/podman/v2/pkg/bindings/pods"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/containers/podman/v2/pkg/specgen"
+)
+
+func (c *Client) CreatePod(ctx context.Context, s *specgen.PodSpecGenerator) (string, error) {
+	var id string
+	err := c.call(ctx, func(connCtx context.Context) error {
+		r, err := pods.CreatePodFromSpec(connCtx, s)
+		if err != nil {
+			return err
+		}
+		id = r.Id
+		return nil
+	})
+	return id, err
+}
+
+func (c *Client) InspectPod(ctx context.Context, nameOrId string) (*entities.PodInspectReport, error) {
+	var report *entities.PodInspectReport
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		report, err = pods.Inspect(connCtx, nameOrId)
+		return err
+	})
+	return report, err
+}
+
+func (c *Client) RemovePod(ctx context.Context, nameOrId string) error {
+	return c.call(ctx, func(connCtx context.Context) error {
+		_, err := pods.Remove(connCtx, nameOrId, newTrue())
+		return err
+	})
+}
@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/bindings"
+)
+
+// SystemInfo is the system info of the service, including fields newer than the v2 bindings
+type SystemInfo struct {
+	*define.Info
+	// NetworkBackend is cni or netavark, reported by Podman 4 and newer
+	NetworkBackend string
+}
+
+func (c *Client) Info(ctx context.Context) (*SystemInfo, error) {
+	// Decoded by hand so that fields unknown to define.Info can be read as well
+	var raw json.RawMessage
+	err := c.call(ctx, func(connCtx context.Context) error {
+		conn, err := bindings.GetClient(connCtx)
+		if err != nil {
+			return err
+		}
+		response, err := conn.DoRequest(nil, http.MethodGet, "/info", nil, nil)
+		if err != nil {
+			return err
+		}
+		return response.Process(&raw)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseSystemInfo(raw)
+}
+
+func parseSystemInfo(raw []byte) (*SystemInfo, error) {
+	info := &SystemInfo{Info: &define.Info{}}
+	if err := json.Unmarshal(raw, info.Info); err != nil {
+		return nil, err
+	}
+	var extra struct {
+		Host struct {
+			NetworkBackend string `json:"networkBackend"`
+		} `json:"host"`
+	}
+	if err := json.Unmarshal(raw, &extra); err != nil {
+		return nil, err
+	}
+	info.NetworkBackend = extra.Host.NetworkBackend
+	if info.NetworkBackend == "" {
+		// Podman before 4.0 only supports CNI
+		info.NetworkBackend = "cni"
+	}
+	return info, nil
+}
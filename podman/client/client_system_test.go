@@ -0,0 +1,30 @@
+package client
+
+import (
+	"testing"
+)
+
+func TestParseSystemInfo(t *testing.T) {
+	info, err := parseSystemInfo([]byte(`{
+		"host": {"rootless": true, "cgroupVersion": "v2", "cpus": 4},
+		"store": {"graphDriverName": "overlay"},
+		"version": {"Version": "2.1.1", "APIVersion": "2.0.0"}
+	}`))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !info.Host.Rootless || info.Host.CGroupsVersion != "v2" || info.Store.GraphDriverName != "overlay" {
+		t.Fatalf("unexpected info %+v", info.Info)
+	}
+	if info.NetworkBackend != "cni" {
+		t.Fatalf("expected cni network backend, got %s", info.NetworkBackend)
+	}
+
+	info, err = parseSystemInfo([]byte(`{"host": {"networkBackend": "netavark"}}`))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if info.NetworkBackend != "netavark" {
+		t.Fatalf("expected netavark network backend, got %s", info.NetworkBackend)
+	}
+}
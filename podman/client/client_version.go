@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blang/semver"
+	"github.com/containers/podman/v2/pkg/bindings/system"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+// Feature is an API feature which is not available in all Podman versions
+type Feature struct {
+	// Name describes the feature in error messages
+	Name       string
+	MinVersion semver.Version
+}
+
+var (
+	// FeatureArchive is copying files between containers and the host
+	FeatureArchive = Feature{Name: "copying files between containers and the host", MinVersion: semver.MustParse("2.2.0")}
+	// FeaturePlayKubeNetwork is connecting the pods of play kube to a network, older services ignore the option
+	FeaturePlayKubeNetwork = Feature{Name: "connecting played pods to a network", MinVersion: semver.MustParse("2.2.0")}
+	// FeatureGenerateSystemd is generating systemd units, including units with new
+	FeatureGenerateSystemd = Feature{Name: "generating systemd units", MinVersion: semver.MustParse("2.1.0")}
+	// FeatureManifestPushAuth is pushing manifest lists with registry credentials, older services ignore them
+	FeatureManifestPushAuth = Feature{Name: "pushing manifest lists with registry credentials", MinVersion: semver.MustParse("3.0.0")}
+	// FeatureCommitChanges is applying Containerfile instructions when committing a container
+	FeatureCommitChanges = Feature{Name: "applying changes when committing a container", MinVersion: semver.MustParse("2.1.0")}
+)
+
+// queryVersion stores the version of the Podman service on the client
+func (c *Client) queryVersion(ctx context.Context) error {
+	var report *entities.SystemVersionReport
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		report, err = system.Version(connCtx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("Unable to query Podman version: %s", err)
+	}
+	version, err := semver.ParseTolerant(report.Server.Version)
+	if err != nil {
+		return fmt.Errorf("Unable to parse Podman version %q: %s", report.Server.Version, err)
+	}
+	c.version = version
+	return nil
+}
+
+// Version returns the version of the Podman service the client is connected to
+func (c *Client) Version() semver.Version {
+	return c.version
+}
+
+// Supports reports whether the Podman service provides the given feature
+func (c *Client) Supports(feature Feature) bool {
+	// Pre-releases of the minimum version are accepted as well
+	v := c.version
+	v.Pre = nil
+	return v.GTE(feature.MinVersion)
+}
+
+// RequireFeature returns an error naming the minimum version if the feature is not supported
+func (c *Client) RequireFeature(feature Feature) error {
+	if c.Supports(feature) {
+		return nil
+	}
+	return fmt.Errorf("%s requires Podman %s or newer, but the service runs %s", feature.Name, feature.MinVersion, c.version)
+}
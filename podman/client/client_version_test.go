@@ -0,0 +1,23 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/blang/semver"
+)
+
+func TestRequireFeature(t *testing.T) {
+	c := &Client{version: semver.MustParse("2.1.1")}
+	if c.Supports(FeatureArchive) {
+		t.Fatalf("2.1.1 must not support %s", FeatureArchive.Name)
+	}
+	err := c.RequireFeature(FeatureArchive)
+	if err == nil || err.Error() != "copying files between containers and the host requires Podman 2.2.0 or newer, but the service runs 2.1.1" {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	c.version = semver.MustParse("2.2.0-rc1")
+	if err := c.RequireFeature(FeatureArchive); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+}
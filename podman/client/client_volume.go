@@ -0,0 +1,32 @@
+package client
+
+import (
+	"context"
+
+	"github.com/containers/podman/v2/pkg/bindings/volumes"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+func (c *Client) CreateVolume(ctx context.Context, options entities.VolumeCreateOptions) (*entities.VolumeConfigResponse, error) {
+	var volume *entities.VolumeConfigResponse
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		volume, err = volumes.Create(connCtx, options)
+		return err
+	})
+	return volume, err
+}
+
+func (c *Client) InspectVolume(ctx context.Context, nameOrId string) (*entities.VolumeConfigResponse, error) {
+	var volume *entities.VolumeConfigResponse
+	err := c.call(ctx, func(connCtx context.Context) (err error) {
+		volume, err = volumes.Inspect(connCtx, nameOrId)
+		return err
+	})
+	return volume, err
+}
+
+func (c *Client) RemoveVolume(ctx context.Context, nameOrId string) error {
+	return c.call(ctx, func(connCtx context.Context) error {
+		return volumes.Remove(connCtx, nameOrId, nil)
+	})
+}
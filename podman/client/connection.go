@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/containers/podman/v2/pkg/bindings"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+var errNoCall = errors.New("request was sent outside of a client call")
+
+// callTransport sends the requests of a connection through the transport of the running call.
+// The v2 bindings send every request with a background context, so the context of a call
+// is bound to its transport instead, and the calls of a client run one after another.
+type callTransport struct {
+	base  *http.Transport
+	calls sync.Mutex
+
+	mu      sync.Mutex
+	current http.RoundTripper
+}
+
+func (t *callTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	current := t.current
+	t.mu.Unlock()
+	if current == nil {
+		return nil, errNoCall
+	}
+	return current.RoundTrip(req)
+}
+
+func (t *callTransport) setCurrent(current http.RoundTripper) {
+	t.mu.Lock()
+	t.current = current
+	t.mu.Unlock()
+}
+
+// useConnection makes the connection of connCtx the one of the client.
+// Its transport is kept as the base of the transports of later calls.
+func (c *Client) useConnection(connCtx context.Context) error {
+	conn, err := bindings.GetClient(connCtx)
+	if err != nil {
+		return err
+	}
+	base, ok := conn.Client.Transport.(*http.Transport)
+	if !ok || base.DialContext == nil {
+		return fmt.Errorf("unsupported transport %T", conn.Client.Transport)
+	}
+	// The connection of the ping is not reused, every call dials its own
+	base.CloseIdleConnections()
+	c.transport = &callTransport{base: base}
+	conn.Client = &http.Client{Transport: c.transport}
+	c.context = connCtx
+	return nil
+}
@@ -0,0 +1,98 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/containers/common/pkg/config"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Config selects the Podman service the client connects to
+type Config struct {
+	// Connection is the name of a connection added with `podman system connection add`
+	Connection string
+	// TLS enables HTTPS for tcp:// endpoints when set
+	TLS *TLSConfig
+	// Retry configures retries of transient API failures
+	Retry RetryConfig
+}
+
+// endpoint is the URI of a Podman service and the SSH identity used for ssh:// URIs
+type endpoint struct {
+	URI      string
+	Identity string
+}
+
+// discoverEndpoint finds the Podman service like the Podman CLI does: a named connection,
+// CONTAINER_HOST, the default connection of containers.conf, then the local rootful or rootless socket.
+func discoverEndpoint(connection string, engine *config.EngineConfig, lookupEnv func(string) (string, bool), euid int) (endpoint, diag.Diagnostics) {
+	if connection != "" {
+		destination, ok := engine.ServiceDestinations[connection]
+		if !ok {
+			names := make([]string, 0, len(engine.ServiceDestinations))
+			for name := range engine.ServiceDestinations {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return endpoint{}, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Podman connection %q not found", connection),
+				Detail: fmt.Sprintf("Known connections: %s. Add it with `podman system connection add %s <destination>`.",
+					strings.Join(names, ", "), connection),
+			}}
+		}
+		return endpoint{URI: destination.URI, Identity: destination.Identity}, nil
+	}
+
+	if uri, ok := lookupEnv("CONTAINER_HOST"); ok && uri != "" {
+		identity, _ := lookupEnv("CONTAINER_SSHKEY")
+		return endpoint{URI: uri, Identity: identity}, nil
+	}
+
+	if engine.ActiveService != "" {
+		destination, ok := engine.ServiceDestinations[engine.ActiveService]
+		if !ok {
+			return endpoint{}, diag.Diagnostics{{
+				Severity: diag.Error,
+				Summary:  fmt.Sprintf("Default Podman connection %q not found", engine.ActiveService),
+				Detail:   "Fix the default connection with `podman system connection default <name>`.",
+			}}
+		}
+		return endpoint{URI: destination.URI, Identity: destination.Identity}, nil
+	}
+	if engine.RemoteURI != "" {
+		return endpoint{URI: engine.RemoteURI, Identity: engine.RemoteIdentity}, nil
+	}
+
+	if euid == 0 {
+		return endpoint{URI: "unix://" + rootfulSocketPath}, nil
+	}
+
+	runtimeDir, _ := lookupEnv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		return endpoint{}, diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  "XDG_RUNTIME_DIR is not set",
+			Detail: "The rootless Podman socket is located at $XDG_RUNTIME_DIR/podman/podman.sock. " +
+				"Run Terraform from a login session of the user owning the containers, or export XDG_RUNTIME_DIR=/run/user/$(id -u). " +
+				"To manage rootful containers, run Terraform as root or set CONTAINER_HOST=unix://" + rootfulSocketPath + ".",
+		}}
+	}
+	return endpoint{URI: "unix://" + runtimeDir + "/podman/podman.sock"}, nil
+}
+
+// unixSocketPath returns the socket path of a unix:// URI, or false for other schemes
+func unixSocketPath(uri string) (string, bool) {
+	u, err := url.Parse(uri)
+	if err != nil || u.Scheme != "unix" {
+		return "", false
+	}
+	// unix://run/podman/podman.sock is accepted by the bindings as well
+	if u.Host != "" {
+		return "/" + u.Host + u.Path, true
+	}
+	return u.Path, true
+}
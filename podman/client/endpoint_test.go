@@ -0,0 +1,66 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/containers/common/pkg/config"
+)
+
+func TestDiscoverEndpoint(t *testing.T) {
+	engine := &config.EngineConfig{
+		ServiceDestinations: map[string]config.Destination{
+			"build": {URI: "ssh://core@build:22/run/user/1000/podman/podman.sock", Identity: "/home/core/.ssh/id_ed25519"},
+			"local": {URI: "unix:///run/podman/podman.sock"},
+		},
+	}
+	env := map[string]string{}
+	lookupEnv := func(key string) (string, bool) {
+		v, ok := env[key]
+		return v, ok
+	}
+
+	assertURI := func(connection string, euid int, expected string) {
+		t.Helper()
+		ep, diags := discoverEndpoint(connection, engine, lookupEnv, euid)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics %v", diags)
+		}
+		if ep.URI != expected {
+			t.Fatalf("expected %s, got %s", expected, ep.URI)
+		}
+	}
+
+	if _, diags := discoverEndpoint("", engine, lookupEnv, 1000); !diags.HasError() || diags[0].Summary != "XDG_RUNTIME_DIR is not set" {
+		t.Fatalf("expected missing XDG_RUNTIME_DIR, got %v", diags)
+	}
+	assertURI("", 0, "unix:///run/podman/podman.sock")
+
+	env["XDG_RUNTIME_DIR"] = "/run/user/1000"
+	assertURI("", 1000, "unix:///run/user/1000/podman/podman.sock")
+
+	engine.ActiveService = "local"
+	assertURI("", 1000, "unix:///run/podman/podman.sock")
+
+	env["CONTAINER_HOST"] = "tcp://podman.example.com:8080"
+	assertURI("", 1000, "tcp://podman.example.com:8080")
+
+	assertURI("build", 1000, "ssh://core@build:22/run/user/1000/podman/podman.sock")
+	if _, diags := discoverEndpoint("missing", engine, lookupEnv, 1000); !diags.HasError() {
+		t.Fatalf("expected an error for an unknown connection")
+	}
+}
+
+func TestUnixSocketPath(t *testing.T) {
+	cases := map[string]string{
+		"unix:///run/podman/podman.sock": "/run/podman/podman.sock",
+		"unix://run/podman/podman.sock":  "/run/podman/podman.sock",
+	}
+	for uri, expected := range cases {
+		if path, ok := unixSocketPath(uri); !ok || path != expected {
+			t.Fatalf("unixSocketPath(%q) = %q, expected %q", uri, path, expected)
+		}
+	}
+	if _, ok := unixSocketPath("ssh://core@build/run/podman/podman.sock"); ok {
+		t.Fatalf("expected no socket path for ssh")
+	}
+}
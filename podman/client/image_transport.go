@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/containers/image/v5/copy"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/transports"
+	"github.com/containers/image/v5/transports/alltransports"
+	"github.com/containers/image/v5/types"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ImageCopyOptions configure CopyImage. Unlike the Client methods, copies are
+// done by the provider itself with containers/image and do not need a Podman socket.
+type ImageCopyOptions struct {
+	SourceAuth           *types.DockerAuthConfig
+	DestinationAuth      *types.DockerAuthConfig
+	SourceTLSVerify      bool
+	DestinationTLSVerify bool
+	// SignaturePolicyPath is the policy.json to enforce, all images are accepted if empty
+	SignaturePolicyPath string
+	// All copies every image of a manifest list instead of the one matching the host
+	All bool
+	// PreserveDigests fails the copy if the destination manifest digest differs from the source
+	PreserveDigests  bool
+	RemoveSignatures bool
+}
+
+func newSystemContext(auth *types.DockerAuthConfig, tlsVerify bool) *types.SystemContext {
+	return &types.SystemContext{
+		DockerAuthConfig:            auth,
+		DockerInsecureSkipTLSVerify: types.NewOptionalBool(!tlsVerify),
+		OCIInsecureSkipTLSVerify:    !tlsVerify,
+	}
+}
+
+func newPolicyContext(policyPath string) (*signature.PolicyContext, error) {
+	policy := &signature.Policy{
+		Default: []signature.PolicyRequirement{signature.NewPRInsecureAcceptAnything()},
+	}
+	if policyPath != "" {
+		var err error
+		if policy, err = signature.NewPolicyFromFile(policyPath); err != nil {
+			return nil, err
+		}
+	}
+	return signature.NewPolicyContext(policy)
+}
+
+// ImageDigest returns the manifest digest of an image reference such as docker://alpine:3.12.
+func ImageDigest(ctx context.Context, rawRef string, sys *types.SystemContext) (digest.Digest, error) {
+	ref, err := alltransports.ParseImageName(rawRef)
+	if err != nil {
+		return "", err
+	}
+	src, err := ref.NewImageSource(ctx, sys)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	rawManifest, _, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	return manifest.Digest(rawManifest)
+}
+
+// destinationManifestTypes lists the manifest types each destination transport can store.
+// Transports that are not listed accept any manifest type.
+var destinationManifestTypes = map[string][]string{
+	"docker": {
+		imgspecv1.MediaTypeImageManifest,
+		manifest.DockerV2Schema2MediaType,
+		imgspecv1.MediaTypeImageIndex,
+		manifest.DockerV2ListMediaType,
+		manifest.DockerV2Schema1SignedMediaType,
+		manifest.DockerV2Schema1MediaType,
+	},
+	"docker-archive": {manifest.DockerV2Schema2MediaType},
+	"oci":            {imgspecv1.MediaTypeImageManifest, imgspecv1.MediaTypeImageIndex},
+	"oci-archive":    {imgspecv1.MediaTypeImageManifest, imgspecv1.MediaTypeImageIndex},
+}
+
+// checkDigestPreservable fails if the destination cannot store the source manifest as is,
+// so that copies with PreserveDigests are rejected before anything is written.
+// Only the source is opened; the destination is never touched.
+// It returns the MIME type of the source manifest.
+func checkDigestPreservable(ctx context.Context, srcRef, destRef types.ImageReference, sourceCtx *types.SystemContext) (string, error) {
+	src, err := srcRef.NewImageSource(ctx, sourceCtx)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+	_, manifestType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+
+	supported, ok := destinationManifestTypes[destRef.Transport().Name()]
+	if !ok {
+		return manifestType, nil
+	}
+	for _, t := range supported {
+		if t == manifestType {
+			return manifestType, nil
+		}
+	}
+	return "", fmt.Errorf("%s does not support manifests of type %s, the digest cannot be preserved", transports.ImageName(destRef), manifestType)
+}
+
+// CopyImage copies an image between two transport references and returns the digest of the copied manifest.
+func CopyImage(ctx context.Context, source, destination string, options ImageCopyOptions) (digest.Digest, error) {
+	srcRef, err := alltransports.ParseImageName(source)
+	if err != nil {
+		return "", fmt.Errorf("invalid source %q: %s", source, err)
+	}
+	destRef, err := alltransports.ParseImageName(destination)
+	if err != nil {
+		return "", fmt.Errorf("invalid destination %q: %s", destination, err)
+	}
+
+	policyContext, err := newPolicyContext(options.SignaturePolicyPath)
+	if err != nil {
+		return "", fmt.Errorf("invalid signature policy: %s", err)
+	}
+	defer policyContext.Destroy()
+
+	sourceCtx := newSystemContext(options.SourceAuth, options.SourceTLSVerify)
+	copyOptions := &copy.Options{
+		SourceCtx:        sourceCtx,
+		DestinationCtx:   newSystemContext(options.DestinationAuth, options.DestinationTLSVerify),
+		RemoveSignatures: options.RemoveSignatures,
+	}
+	if options.All || options.PreserveDigests {
+		copyOptions.ImageListSelection = copy.CopyAllImages
+	}
+
+	if options.PreserveDigests {
+		manifestType, err := checkDigestPreservable(ctx, srcRef, destRef, sourceCtx)
+		if err != nil {
+			return "", err
+		}
+		// A forced list type would also be forced onto the instances, so only single images are pinned
+		if !manifest.MIMETypeIsMultiImage(manifestType) {
+			copyOptions.ForceManifestMIMEType = manifestType
+		}
+	}
+
+	copiedManifest, err := copy.Image(ctx, policyContext, destRef, srcRef, copyOptions)
+	if err != nil {
+		return "", err
+	}
+	copiedDigest, err := manifest.Digest(copiedManifest)
+	if err != nil {
+		return "", err
+	}
+
+	if options.PreserveDigests {
+		// Instances of a manifest list can still be converted, which changes the digest of the list
+		sourceDigest, err := ImageDigest(ctx, source, sourceCtx)
+		if err != nil {
+			return "", err
+		}
+		if sourceDigest != copiedDigest {
+			return "", fmt.Errorf("digest of %s changed from %s to %s during copy", destination, sourceDigest, copiedDigest)
+		}
+	}
+
+	return copiedDigest, nil
+}
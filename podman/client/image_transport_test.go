@@ -0,0 +1,88 @@
+package client
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/containers/image/v5/manifest"
+	"github.com/opencontainers/go-digest"
+)
+
+// writeDirImage writes a single-layer image in the layout of the dir: transport
+func writeDirImage(t *testing.T, dir string) digest.Digest {
+	writeBlob := func(content []byte) digest.Digest {
+		d := digest.FromBytes(content)
+		if err := ioutil.WriteFile(filepath.Join(dir, d.Hex()), content, 0644); err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		return d
+	}
+
+	var layer bytes.Buffer
+	tw := tar.NewWriter(&layer)
+	content := []byte("hello\n")
+	if err := tw.WriteHeader(&tar.Header{Name: "hello.txt", Mode: 0644, Size: int64(len(content))}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	tw.Write(content)
+	tw.Close()
+	layerDigest := writeBlob(layer.Bytes())
+
+	config := []byte(`{"architecture":"amd64","os":"linux","rootfs":{"type":"layers","diff_ids":["` + layerDigest.String() + `"]}}`)
+	configDigest := writeBlob(config)
+
+	m := manifest.Schema2FromComponents(
+		manifest.Schema2Descriptor{MediaType: manifest.DockerV2Schema2ConfigMediaType, Size: int64(len(config)), Digest: configDigest},
+		[]manifest.Schema2Descriptor{{MediaType: manifest.DockerV2SchemaLayerMediaTypeUncompressed, Size: int64(layer.Len()), Digest: layerDigest}},
+	)
+	rawManifest, err := json.Marshal(m)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "manifest.json"), rawManifest, 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "version"), []byte("Directory Transport Version: 1.1\n"), 0644); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	return digest.FromBytes(rawManifest)
+}
+
+func TestCopyImage(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "terraform-podman-copy")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	src := filepath.Join(tmp, "src")
+	if err := os.Mkdir(src, 0755); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	sourceDigest := writeDirImage(t, src)
+
+	copiedDigest, err := CopyImage(context.Background(), "dir:"+src, "dir:"+filepath.Join(tmp, "dest"), ImageCopyOptions{PreserveDigests: true})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if copiedDigest != sourceDigest {
+		t.Fatalf("expected digest %s, got %s", sourceDigest, copiedDigest)
+	}
+
+	if _, err := CopyImage(context.Background(), "dir:"+src, "oci:"+filepath.Join(tmp, "oci")+":latest", ImageCopyOptions{}); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if _, err := CopyImage(context.Background(), "dir:"+src, "oci:"+filepath.Join(tmp, "oci-preserve")+":latest", ImageCopyOptions{PreserveDigests: true}); err == nil {
+		t.Fatalf("expected an error when the manifest is converted with preserve digests")
+	}
+	if _, err := os.Stat(filepath.Join(tmp, "oci-preserve")); !os.IsNotExist(err) {
+		t.Fatalf("expected nothing to be written when the digest cannot be preserved")
+	}
+}
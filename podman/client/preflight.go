@@ -0,0 +1,84 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+const rootfulSocketPath = "/run/podman/podman.sock"
+
+const enableSocketAdvice = "Enable the Podman API service for your user with `systemctl --user enable --now podman.socket`. " +
+	"To manage rootful containers instead, run `sudo systemctl enable --now podman.socket` and set CONTAINER_HOST=unix://" + rootfulSocketPath + "."
+
+// ConnectionError is returned by Connect when the Podman socket cannot be used
+type ConnectionError struct {
+	Diagnostics diag.Diagnostics
+}
+
+func (e *ConnectionError) Error() string {
+	messages := make([]string, 0, len(e.Diagnostics))
+	for _, d := range e.Diagnostics {
+		if d.Detail == "" {
+			messages = append(messages, d.Summary)
+			continue
+		}
+		messages = append(messages, d.Summary+": "+d.Detail)
+	}
+	return strings.Join(messages, "\n")
+}
+
+// socketPreflight checks that the Podman service listens on socketPath and explains how to fix it if not.
+func socketPreflight(socketPath string) diag.Diagnostics {
+	info, err := os.Stat(socketPath)
+	switch {
+	case os.IsNotExist(err):
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Podman socket %s does not exist", socketPath),
+			Detail:   enableSocketAdvice,
+		}}
+	case os.IsPermission(err):
+		return socketPermissionDenied(socketPath)
+	case err != nil:
+		return diag.FromErr(err)
+	case info.Mode()&os.ModeSocket == 0:
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("%s is not a socket", socketPath),
+			Detail:   "Remove the file and restart the Podman API service with `systemctl --user restart podman.socket`.",
+		}}
+	}
+
+	conn, err := net.DialTimeout("unix", socketPath, 5*time.Second)
+	switch {
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return diag.Diagnostics{{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("Podman service is not listening on %s", socketPath),
+			Detail:   "The socket exists but the service is stopped. " + enableSocketAdvice,
+		}}
+	case errors.Is(err, syscall.EACCES):
+		return socketPermissionDenied(socketPath)
+	case err != nil:
+		return diag.FromErr(err)
+	}
+	conn.Close()
+
+	return nil
+}
+
+func socketPermissionDenied(socketPath string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Permission denied on Podman socket %s", socketPath),
+		Detail: "The socket belongs to another user. Run Terraform as the user owning the rootless Podman service, " +
+			"or as root for the rootful socket " + rootfulSocketPath + ".",
+	}}
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSocketPreflight(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "preflight")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	assertSummary := func(socketPath, expected string) {
+		t.Helper()
+		diags := socketPreflight(socketPath)
+		if !diags.HasError() || !strings.HasPrefix(diags[0].Summary, expected) {
+			t.Fatalf("expected %q for %s, got %v", expected, socketPath, diags)
+		}
+	}
+
+	socketPath := filepath.Join(tmp, "podman.sock")
+	assertSummary(socketPath, "Podman socket "+socketPath+" does not exist")
+
+	if err := ioutil.WriteFile(socketPath, nil, 0600); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	assertSummary(socketPath, socketPath+" is not a socket")
+	os.Remove(socketPath)
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diags := socketPreflight(socketPath); diags.HasError() {
+		t.Fatalf("unexpected diagnostics %v", diags)
+	}
+
+	listener.(*net.UnixListener).SetUnlinkOnClose(false)
+	listener.Close()
+	assertSummary(socketPath, "Podman service is not listening on "+socketPath)
+}
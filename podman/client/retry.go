@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+// RetryConfig configures retries of transient API failures
+type RetryConfig struct {
+	// MaxRetries is the number of retries after the first attempt, 0 disables retries
+	MaxRetries int
+	// MaxWait caps the exponentially growing wait between attempts, it must be positive
+	MaxWait time.Duration
+}
+
+const retryInitialWait = 500 * time.Millisecond
+
+// transientPullMessages are registry errors reported by the service while pulling an image
+var transientPullMessages = []string{
+	"toomanyrequests",
+	"429 Too Many Requests",
+	"500 Internal Server Error",
+	"502 Bad Gateway",
+	"503 Service Unavailable",
+	"504 Gateway Timeout",
+}
+
+// retryTransport retries requests which failed for a transient reason, see retryable.
+// It is the only layer retrying failed requests: DoRequest of the bindings sends a request
+// up to three times on any error, so a request that failed here fails again right away
+// instead of being sent once more.
+type retryTransport struct {
+	base   http.RoundTripper
+	config RetryConfig
+	ctx    context.Context
+
+	mu     sync.Mutex
+	failed map[*http.Request]error
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	err, failed := t.failed[req]
+	t.mu.Unlock()
+	if failed {
+		return nil, err
+	}
+
+	resp, err := t.roundTrip(req)
+	if err != nil {
+		t.mu.Lock()
+		if t.failed == nil {
+			t.failed = map[*http.Request]error{}
+		}
+		t.failed[req] = err
+		t.mu.Unlock()
+	}
+	return resp, err
+}
+
+func (t *retryTransport) roundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if attempt >= t.config.MaxRetries || t.ctx.Err() != nil || !retryable(req, resp, err) {
+			return resp, err
+		}
+
+		// Requests with a streamed body cannot be sent again
+		next := req
+		if req.Body != nil {
+			if req.GetBody == nil {
+				return resp, err
+			}
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, err
+			}
+			next = req.Clone(req.Context())
+			next.Body = body
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(t.config.backoff(attempt)):
+		case <-t.ctx.Done():
+			return nil, t.ctx.Err()
+		}
+		req = next
+	}
+}
+
+func (r RetryConfig) backoff(attempt int) time.Duration {
+	wait := retryInitialWait << uint(attempt)
+	if wait <= 0 || (r.MaxWait > 0 && wait > r.MaxWait) {
+		return r.MaxWait
+	}
+	return wait
+}
+
+// retryCall calls fn again while it fails with an error for which transient returns true.
+// It is used for failures the transport does not retry, like errors in streamed responses
+// or container operations the service refused without acting on them.
+func (c *Client) retryCall(ctx context.Context, transient func(error) bool, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= c.config.Retry.MaxRetries || ctx.Err() != nil || !transient(err) {
+			return err
+		}
+		select {
+		case <-time.After(c.config.Retry.backoff(attempt)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// isTransientPullError reports whether a pull failed because of a rate limit or server error of the registry
+func isTransientPullError(err error) bool {
+	for _, m := range transientPullMessages {
+		if strings.Contains(err.Error(), m) {
+			return true
+		}
+	}
+	return false
+}
+
+// isImproperStateError reports whether the service refused a container operation because of the
+// state the container was in at that moment. Nothing was done, so sending the operation again is safe.
+func isImproperStateError(err error) bool {
+	e, ok := err.(entities.ErrorModel)
+	if !ok || (e.ResponseCode != http.StatusConflict && e.ResponseCode != http.StatusInternalServerError) {
+		return false
+	}
+	return e.Because == define.ErrCtrStateInvalid.Error()
+}
+
+// isSafeMethod reports whether sending the request again cannot change the outcome
+func isSafeMethod(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead
+}
+
+// isDialError reports whether err happened while connecting, before the request was sent
+func isDialError(err error) bool {
+	var opErr *net.OpError
+	return (errors.As(err, &opErr) && opErr.Op == "dial") || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+// retryable reports whether a request failed for a transient reason.
+// Requests which never reached the service are retried regardless of the method,
+// others only if they are reads, as the service may have acted on them already.
+func retryable(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		if isDialError(err) {
+			return true
+		}
+		return isSafeMethod(req) && (errors.Is(err, syscall.ECONNRESET) ||
+			errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF))
+	}
+
+	if !isSafeMethod(req) {
+		return false
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
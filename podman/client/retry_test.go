@@ -0,0 +1,228 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v2/pkg/bindings"
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+func newResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// countAttempts sends a request through a retry transport whose responses are given in order
+func countAttempts(t *testing.T, method, path, body string, responses ...func() (*http.Response, error)) (int, *http.Response, error) {
+	t.Helper()
+	attempts := 0
+	transport := &retryTransport{
+		base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if req.Body != nil {
+				content, _ := ioutil.ReadAll(req.Body)
+				if string(content) != body {
+					t.Fatalf("attempt %d sent body %q", attempts, content)
+				}
+			}
+			attempts++
+			return responses[attempts-1]()
+		}),
+		config: RetryConfig{MaxRetries: len(responses) - 1, MaxWait: time.Millisecond},
+		ctx:    context.Background(),
+	}
+
+	var reqBody *strings.Reader
+	req, err := http.NewRequest(method, "http://d/v2.0.0/libpod"+path, nil)
+	if body != "" {
+		reqBody = strings.NewReader(body)
+		req, err = http.NewRequest(method, "http://d/v2.0.0/libpod"+path, reqBody)
+	}
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	resp, err := transport.RoundTrip(req)
+	return attempts, resp, err
+}
+
+func respond(status int, body string) func() (*http.Response, error) {
+	return func() (*http.Response, error) {
+		return newResponse(status, body), nil
+	}
+}
+
+func TestRetryTransport(t *testing.T) {
+	attempts, resp, err := countAttempts(t, http.MethodGet, "/info", "",
+		respond(http.StatusServiceUnavailable, ""),
+		func() (*http.Response, error) { return nil, syscall.ECONNRESET },
+		respond(http.StatusOK, "{}"))
+	if err != nil || attempts != 3 || resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected a successful third attempt, got %d attempts, %v, %v", attempts, resp, err)
+	}
+
+	// Registry errors of a pull are retried by retryCall only
+	attempts, _, _ = countAttempts(t, http.MethodPost, "/images/pull", `{"reference":"alpine"}`,
+		respond(http.StatusInternalServerError, `{"message":"toomanyrequests: You have reached your pull rate limit"}`),
+		respond(http.StatusOK, "{}"))
+	if attempts != 1 {
+		t.Fatalf("expected a rate limited pull not to be retried by the transport, got %d attempts", attempts)
+	}
+
+	attempts, resp, err = countAttempts(t, http.MethodPost, "/containers/create", `{}`,
+		func() (*http.Response, error) {
+			return nil, &net.OpError{Op: "dial", Net: "unix", Err: syscall.ENOENT}
+		},
+		respond(http.StatusCreated, ""))
+	if err != nil || attempts != 2 || resp.StatusCode != http.StatusCreated {
+		t.Fatalf("expected a create which was never sent to be retried, got %d attempts, %v", attempts, err)
+	}
+
+	attempts, _, _ = countAttempts(t, http.MethodPost, "/containers/create", `{}`,
+		func() (*http.Response, error) { return nil, syscall.ECONNRESET },
+		respond(http.StatusCreated, ""))
+	if attempts != 1 {
+		t.Fatalf("expected a create with a reset connection not to be retried, got %d attempts", attempts)
+	}
+
+	attempts, resp, _ = countAttempts(t, http.MethodPost, "/containers/create", `{}`,
+		respond(http.StatusInternalServerError, `{"cause":"name is already in use"}`),
+		respond(http.StatusCreated, ""))
+	if attempts != 1 {
+		t.Fatalf("expected a failed create not to be retried, got %d attempts", attempts)
+	}
+	if content, _ := ioutil.ReadAll(resp.Body); string(content) != `{"cause":"name is already in use"}` {
+		t.Fatalf("expected the error body to be preserved, got %q", content)
+	}
+
+	attempts, _, _ = countAttempts(t, http.MethodGet, "/containers/web/archive", "",
+		respond(http.StatusNotImplemented, ""),
+		respond(http.StatusOK, ""))
+	if attempts != 1 {
+		t.Fatalf("expected 501 not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestRetryTransportFailsResentRequests(t *testing.T) {
+	attempts := 0
+	transport := &retryTransport{
+		base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			attempts++
+			return nil, syscall.ECONNRESET
+		}),
+		config: RetryConfig{MaxRetries: 2, MaxWait: time.Millisecond},
+		ctx:    context.Background(),
+	}
+	req, err := http.NewRequest(http.MethodPost, "http://d/v2.0.0/libpod/containers/create", nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// DoRequest of the bindings sends a failed request up to three times
+	for i := 0; i < 3; i++ {
+		if _, err := transport.RoundTrip(req); err != syscall.ECONNRESET {
+			t.Fatalf("expected %s, got %v", syscall.ECONNRESET, err)
+		}
+	}
+	if attempts != 1 {
+		t.Fatalf("expected the request to be sent once, got %d attempts", attempts)
+	}
+}
+
+func TestRetryBackoff(t *testing.T) {
+	config := RetryConfig{MaxRetries: 10, MaxWait: 3 * time.Second}
+	expected := []time.Duration{500 * time.Millisecond, time.Second, 2 * time.Second, 3 * time.Second, 3 * time.Second}
+	for attempt, wait := range expected {
+		if actual := config.backoff(attempt); actual != wait {
+			t.Fatalf("attempt %d: expected %s, got %s", attempt, wait, actual)
+		}
+	}
+}
+
+func TestRetryCall(t *testing.T) {
+	c := &Client{
+		config: Config{Retry: RetryConfig{MaxRetries: 2, MaxWait: time.Millisecond}},
+	}
+
+	attempts := 0
+	err := c.retryCall(context.Background(), isTransientPullError, func() error {
+		attempts++
+		return errors.New("reading manifest latest: received unexpected HTTP status: 503 Service Unavailable")
+	})
+	if err == nil || attempts != 3 {
+		t.Fatalf("expected 3 attempts and an error, got %d attempts, %v", attempts, err)
+	}
+
+	attempts = 0
+	err = c.retryCall(context.Background(), isTransientPullError, func() error {
+		attempts++
+		return errors.New("manifest unknown")
+	})
+	if err == nil || attempts != 1 {
+		t.Fatalf("expected a permanent error not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestRetryImproperState(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "retry")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	listener, err := net.Listen("unix", filepath.Join(tmp, "podman.sock"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	starts := 0
+	go http.Serve(listener, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/containers/web/start"):
+			starts++
+			if starts == 1 {
+				// The service refuses the start while the container is still stopping
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"cause":"container state improper","message":"container web is stopping","response":500}`))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/containers/web/wait"):
+			w.Write([]byte("0"))
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+
+	connCtx, err := bindings.NewConnection(context.Background(), "unix://"+listener.Addr().String())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	c := &Client{config: Config{Retry: RetryConfig{MaxRetries: 2, MaxWait: time.Millisecond}}}
+	if err := c.useConnection(connCtx); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if err := c.StartContainer(context.Background(), "web"); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if starts != 2 {
+		t.Fatalf("expected a refused start to be sent again, got %d attempts", starts)
+	}
+
+	if isImproperStateError(entities.ErrorModel{Because: "no such container", ResponseCode: http.StatusNotFound}) {
+		t.Fatalf("expected other errors not to be retried")
+	}
+	if !isImproperStateError(entities.ErrorModel{Because: "container state improper", ResponseCode: http.StatusConflict}) {
+		t.Fatalf("expected a conflict because of the container state to be retried")
+	}
+}
@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/containers/podman/v2/pkg/bindings"
+)
+
+// TLSConfig holds the PEM encoded material for HTTPS connections to tcp:// endpoints
+type TLSConfig struct {
+	CAMaterial         []byte
+	CertMaterial       []byte
+	KeyMaterial        []byte
+	InsecureSkipVerify bool
+}
+
+func (t *TLSConfig) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+	if len(t.CAMaterial) > 0 {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(t.CAMaterial) {
+			return nil, errors.New("CA material does not contain a PEM encoded certificate")
+		}
+		config.RootCAs = pool
+	}
+	if len(t.CertMaterial) > 0 || len(t.KeyMaterial) > 0 {
+		cert, err := tls.X509KeyPair(t.CertMaterial, t.KeyMaterial)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid client certificate: %s", err)
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// dialTimeout bounds establishing a connection to a tcp:// endpoint, including the TLS handshake
+const dialTimeout = 30 * time.Second
+
+// dialTLS opens a TLS connection to address, aborting once ctx is done or dialTimeout has passed
+func dialTLS(ctx context.Context, address string, config *tls.Config) (net.Conn, error) {
+	ctx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+	raw, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	conn := tls.Client(raw, config)
+	// The handshake has no context of its own, so it is bounded by the deadline of ctx
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			raw.Close()
+		case <-done:
+		}
+	}()
+	if err := conn.Handshake(); err != nil {
+		raw.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, err
+	}
+	return conn, nil
+}
+
+// newTLSConnection connects to a tcp:// endpoint over HTTPS.
+// The v2 bindings only speak plain HTTP over tcp://, so the connection is built here
+// with a transport dialing TLS and checked with the same ping NewConnection sends.
+func newTLSConnection(ctx context.Context, uri string, config *tls.Config) (context.Context, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "tcp" {
+		return nil, fmt.Errorf("TLS settings require a tcp:// endpoint, got %s", uri)
+	}
+	if config.ServerName == "" {
+		config = config.Clone()
+		config.ServerName = u.Hostname()
+	}
+
+	transport := &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialTLS(ctx, u.Host, config)
+		},
+		DisableCompression: true,
+	}
+	// The bindings send the ping with a background context, so its dial is bound to ctx
+	pingTransport := transport.Clone()
+	pingTransport.DialContext = func(_ context.Context, _, _ string) (net.Conn, error) {
+		return dialTLS(ctx, u.Host, config)
+	}
+	defer pingTransport.CloseIdleConnections()
+
+	conn := &bindings.Connection{URI: u, Client: &http.Client{Transport: pingTransport}}
+	response, err := conn.DoRequest(nil, http.MethodGet, "../../../_ping", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ping response was %d", response.StatusCode)
+	}
+	conn.Client = &http.Client{Transport: transport}
+
+	// NewConnection cannot take a transport, but the requests of DoRequest carry their connection
+	// for the bindings, so the context of the ping is the connection context
+	return response.Request.Context(), nil
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/containers/podman/v2/pkg/bindings"
+)
+
+func TestNewTLSConnection(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	config, err := (&TLSConfig{CAMaterial: ca}).tlsConfig()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	uri := "tcp://" + strings.TrimPrefix(server.URL, "https://")
+	ctx, err := newTLSConnection(context.Background(), uri, config)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// Requests after the ping must reach the TLS endpoint directly
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	response, err := conn.DoRequest(nil, http.MethodGet, "/info", nil, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		t.Fatalf("unexpected status %d", response.StatusCode)
+	}
+
+	if _, err := newTLSConnection(context.Background(), "unix:///run/podman/podman.sock", config); err == nil {
+		t.Fatalf("expected an error for a unix endpoint")
+	}
+}
+
+func TestTLSConfigRejectsUntrustedServer(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config, err := (&TLSConfig{}).tlsConfig()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	uri := "tcp://" + strings.TrimPrefix(server.URL, "https://")
+	if _, err := newTLSConnection(context.Background(), uri, config); err == nil {
+		t.Fatalf("expected the self-signed server certificate to be rejected")
+	}
+}
+
+func TestDialTLSHonorsContext(t *testing.T) {
+	// A server that accepts connections but never answers the handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := dialTLS(ctx, listener.Addr().String(), &tls.Config{InsecureSkipVerify: true}); err != context.DeadlineExceeded {
+		t.Fatalf("expected %s, got %v", context.DeadlineExceeded, err)
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/saitho/terraform-provider-podman/podman/client"
+)
+
+// ProviderConfig is the configuration of the provider passed to resources as meta
+type ProviderConfig struct {
+	// ClientConfig selects the Podman service resources connect to
+	ClientConfig client.Config
+	// RegistryAuth maps registry hosts to their credentials
+	RegistryAuth map[string]types.DockerAuthConfig
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	config := &ProviderConfig{
+		ClientConfig: client.Config{
+			Connection: d.Get("connection").(string),
+		},
+		RegistryAuth: map[string]types.DockerAuthConfig{},
+	}
+
+	retryMaxWait, err := time.ParseDuration(d.Get("retry_max_wait").(string))
+	if err != nil {
+		return nil, fmt.Errorf("Invalid retry_max_wait: %s", err)
+	}
+	config.ClientConfig.Retry = client.RetryConfig{
+		MaxRetries: d.Get("max_retries").(int),
+		MaxWait:    retryMaxWait,
+	}
+
+	tlsConfig, err := providerTLSConfig(d)
+	if err != nil {
+		return nil, err
+	}
+	config.ClientConfig.TLS = tlsConfig
+
+	for _, rawAuth := range d.Get("registry_auth").(*schema.Set).List() {
+		rawAuth := rawAuth.(map[string]interface{})
+		config.RegistryAuth[normalizeRegistryAddress(rawAuth["address"].(string))] = types.DockerAuthConfig{
+			Username: rawAuth["username"].(string),
+			Password: rawAuth["password"].(string),
+		}
+	}
+
+	return config, nil
+}
+
+// providerTLSConfig returns the TLS settings for tcp:// endpoints, or nil if none are configured.
+// Any TLS argument turns TLS on, the system roots verify the service when no CA is given.
+func providerTLSConfig(d *schema.ResourceData) (*client.TLSConfig, error) {
+	tlsConfig := &client.TLSConfig{}
+	enabled := false
+	if v, ok := d.GetOkExists("tls_verify"); ok {
+		tlsConfig.InsecureSkipVerify = !v.(bool)
+		enabled = true
+	}
+
+	materials := []struct {
+		material, file string
+		target         *[]byte
+	}{
+		{"ca_material", "ca_file", &tlsConfig.CAMaterial},
+		{"cert_material", "cert_file", &tlsConfig.CertMaterial},
+		{"key_material", "key_file", &tlsConfig.KeyMaterial},
+	}
+	for _, m := range materials {
+		if v := d.Get(m.material).(string); v != "" {
+			*m.target = []byte(v)
+		} else if path := d.Get(m.file).(string); path != "" {
+			content, err := ioutil.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("Unable to read %s: %s", m.file, err)
+			}
+			*m.target = content
+		}
+		if len(*m.target) > 0 {
+			enabled = true
+		}
+	}
+
+	if !enabled {
+		return nil, nil
+	}
+	return tlsConfig, nil
+}
+
+// newClient returns an unconnected client for the configured Podman service
+func (c *ProviderConfig) newClient() *client.Client {
+	return client.NewClient(c.ClientConfig)
+}
+
+// connectionDiagnostics returns the diagnostics of a failed connection, keeping the advice of a preflight failure
+func connectionDiagnostics(err error) diag.Diagnostics {
+	var connErr *client.ConnectionError
+	if errors.As(err, &connErr) {
+		return connErr.Diagnostics
+	}
+	return diag.FromErr(err)
+}
+
+// normalizeRegistryAddress strips the scheme and path from a registry address
+func normalizeRegistryAddress(address string) string {
+	address = strings.TrimPrefix(address, "https://")
+	address = strings.TrimPrefix(address, "http://")
+	address = strings.SplitN(address, "/", 2)[0]
+	switch address {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
+	}
+	return address
+}
+
+// authForImage returns the credentials configured for the registry of image, or nil
+func (c *ProviderConfig) authForImage(image string) *types.DockerAuthConfig {
+	named, err := reference.ParseNormalizedNamed(image)
+	if err != nil {
+		return nil
+	}
+	if auth, ok := c.RegistryAuth[reference.Domain(named)]; ok {
+		return &auth
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/containers/image/v5/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNormalizeRegistryAddress(t *testing.T) {
+	cases := map[string]string{
+		"https://index.docker.io/v1/": "docker.io",
+		"registry-1.docker.io":        "docker.io",
+		"http://localhost:5000":       "localhost:5000",
+		"quay.io":                     "quay.io",
+	}
+	for address, expected := range cases {
+		if actual := normalizeRegistryAddress(address); actual != expected {
+			t.Fatalf("normalizeRegistryAddress(%q) = %q, expected %q", address, actual, expected)
+		}
+	}
+}
+
+func TestAuthForImage(t *testing.T) {
+	config := &ProviderConfig{
+		RegistryAuth: map[string]types.DockerAuthConfig{
+			"docker.io":      {Username: "hub"},
+			"localhost:5000": {Username: "local"},
+		},
+	}
+
+	if auth := config.authForImage("alpine:3.12"); auth == nil || auth.Username != "hub" {
+		t.Fatalf("expected docker.io credentials for alpine, got %v", auth)
+	}
+	if auth := config.authForImage("localhost:5000/app"); auth == nil || auth.Username != "local" {
+		t.Fatalf("expected localhost:5000 credentials, got %v", auth)
+	}
+	if auth := config.authForImage("quay.io/podman/stable"); auth != nil {
+		t.Fatalf("expected no credentials for quay.io, got %v", auth)
+	}
+}
+
+func TestProviderTLSConfig(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, New().Schema, map[string]interface{}{})
+	tlsConfig, err := providerTLSConfig(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if tlsConfig != nil {
+		t.Fatalf("expected TLS to be disabled without settings, got %v", tlsConfig)
+	}
+
+	// tls_verify alone uses TLS with the system roots instead of plain HTTP
+	for _, verify := range []bool{true, false} {
+		d := schema.TestResourceDataRaw(t, New().Schema, map[string]interface{}{"tls_verify": verify})
+		tlsConfig, err := providerTLSConfig(d)
+		if err != nil {
+			t.Fatalf("err: %s", err)
+		}
+		if tlsConfig == nil || tlsConfig.CAMaterial != nil || tlsConfig.InsecureSkipVerify == verify {
+			t.Fatalf("expected TLS with tls_verify = %t, got %+v", verify, tlsConfig)
+		}
+	}
+
+	caFile, err := ioutil.TempFile("", "ca")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.Remove(caFile.Name())
+	caFile.WriteString("ca")
+	caFile.Close()
+
+	d.Set("ca_file", caFile.Name())
+	d.Set("cert_material", "cert")
+	tlsConfig, err = providerTLSConfig(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if tlsConfig == nil || string(tlsConfig.CAMaterial) != "ca" || string(tlsConfig.CertMaterial) != "cert" || tlsConfig.InsecureSkipVerify {
+		t.Fatalf("unexpected TLS config %+v", tlsConfig)
+	}
+}
+
+func TestConnectionDiagnostics(t *testing.T) {
+	dir, err := ioutil.TempDir("", "podman-socket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	oldHost, hadHost := os.LookupEnv("CONTAINER_HOST")
+	os.Setenv("CONTAINER_HOST", "unix://"+filepath.Join(dir, "podman.sock"))
+	defer func() {
+		if hadHost {
+			os.Setenv("CONTAINER_HOST", oldHost)
+		} else {
+			os.Unsetenv("CONTAINER_HOST")
+		}
+	}()
+
+	// Data sources keep the advice of the preflight like the container resource does
+	d := schema.TestResourceDataRaw(t, dataSourcePodmanInfo().Schema, map[string]interface{}{})
+	diags := dataSourcePodmanInfoRead(context.Background(), d, &ProviderConfig{})
+	if len(diags) != 1 || !strings.Contains(diags[0].Summary, "does not exist") || diags[0].Detail == "" {
+		t.Fatalf("expected the socket preflight diagnostics, got %v", diags)
+	}
+}
@@ -0,0 +1,105 @@
+package provider
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePodmanContainerFile() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanContainerFileRead,
+
+		Schema: map[string]*schema.Schema{
+			"container": {
+				Type:        schema.TypeString,
+				Description: "Name or ID of the container",
+				Required:    true,
+			},
+
+			"path": {
+				Type:             schema.TypeString,
+				Description:      "Absolute path of the file or directory in the container",
+				Required:         true,
+				ValidateDiagFunc: validateDockerContainerPath,
+			},
+
+			"archive": {
+				Type:        schema.TypeBool,
+				Description: "Whether path is a directory, in which case content_base64 holds a tar archive of it",
+				Computed:    true,
+			},
+
+			"content": {
+				Type:        schema.TypeString,
+				Description: "Content of the file, empty for directories",
+				Computed:    true,
+				Sensitive:   true,
+			},
+
+			"content_base64": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+// singleFileFromTar returns the content of the archive if it holds exactly one regular file
+func singleFileFromTar(archive []byte) ([]byte, bool, error) {
+	tr := tar.NewReader(bytes.NewReader(archive))
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, false, err
+	}
+	if hdr.Typeflag != tar.TypeReg {
+		return nil, false, nil
+	}
+	content, err := ioutil.ReadAll(tr)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, err := tr.Next(); err != io.EOF {
+		return nil, false, err
+	}
+	return content, true, nil
+}
+
+func dataSourcePodmanContainerFileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	container := d.Get("container").(string)
+	path := d.Get("path").(string)
+	archive, err := podmanClient.CopyFromContainer(ctx, container, path)
+	if err != nil {
+		return diag.Errorf("Unable to copy %s from container %s: %s", path, container, err)
+	}
+
+	content, isFile, err := singleFileFromTar(archive)
+	if err != nil {
+		return diag.Errorf("Unable to read archive of %s: %s", path, err)
+	}
+
+	d.SetId(container + ":" + path)
+	if isFile {
+		d.Set("archive", false)
+		d.Set("content", string(content))
+		d.Set("content_base64", base64.StdEncoding.EncodeToString(content))
+	} else {
+		d.Set("archive", true)
+		d.Set("content", "")
+		d.Set("content_base64", base64.StdEncoding.EncodeToString(archive))
+	}
+
+	return nil
+}
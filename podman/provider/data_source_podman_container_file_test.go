@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+)
+
+func TestSingleFileFromTar(t *testing.T) {
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	tw.WriteHeader(&tar.Header{Name: "ca.pem", Mode: 0644, Size: 5, Typeflag: tar.TypeReg})
+	tw.Write([]byte("cert\n"))
+	tw.Close()
+
+	content, isFile, err := singleFileFromTar(buf.Bytes())
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if !isFile || string(content) != "cert\n" {
+		t.Fatalf("expected a single file, got %v %q", isFile, content)
+	}
+
+	buf.Reset()
+	tw = tar.NewWriter(buf)
+	tw.WriteHeader(&tar.Header{Name: "certs/", Mode: 0755, Typeflag: tar.TypeDir})
+	tw.WriteHeader(&tar.Header{Name: "certs/ca.pem", Mode: 0644, Size: 5, Typeflag: tar.TypeReg})
+	tw.Write([]byte("cert\n"))
+	tw.Close()
+
+	if _, isFile, err := singleFileFromTar(buf.Bytes()); err != nil || isFile {
+		t.Fatalf("expected a directory archive, got %v %v", isFile, err)
+	}
+}
@@ -0,0 +1,167 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePodmanContainers() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanContainersRead,
+
+		Schema: map[string]*schema.Schema{
+			"all": {
+				Type:        schema.TypeBool,
+				Description: "Also list containers which are not running, required to match statuses other than running",
+				Optional:    true,
+				Default:     false,
+			},
+
+			"label": {
+				Type:        schema.TypeList,
+				Description: "Labels the containers must have, in the form key or key=value",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Regular expression the container name must match",
+				Optional:    true,
+			},
+
+			"status": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateStringMatchesPattern(`^(created|running|paused|stopped|exited|unknown)$`),
+				},
+			},
+
+			"ancestor": {
+				Type:        schema.TypeString,
+				Description: "Image name or ID the containers were created from",
+				Optional:    true,
+			},
+
+			"pod": {
+				Type:        schema.TypeString,
+				Description: "Name or ID of the pod the containers belong to",
+				Optional:    true,
+			},
+
+			"network": {
+				Type:        schema.TypeString,
+				Description: "Name of a network the containers are connected to",
+				Optional:    true,
+			},
+
+			"containers": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"names": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"image": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"labels": {
+							Type:     schema.TypeMap,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"ports": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"internal": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"external": {
+										Type:     schema.TypeInt,
+										Computed: true,
+									},
+									"ip": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+									"protocol": {
+										Type:     schema.TypeString,
+										Computed: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePodmanContainersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	filters := listFilters(d, map[string]string{
+		"label":    "label",
+		"name":     "name",
+		"status":   "status",
+		"ancestor": "ancestor",
+		"pod":      "pod",
+		"network":  "network",
+	})
+	list, err := podmanClient.ListContainers(ctx, filters, d.Get("all").(bool))
+	if err != nil {
+		return diag.Errorf("Unable to list containers: %s", err)
+	}
+
+	ids := make([]string, 0, len(list))
+	containers := make([]interface{}, 0, len(list))
+	for _, c := range list {
+		ports := make([]interface{}, 0, len(c.Ports))
+		for _, p := range c.Ports {
+			ports = append(ports, map[string]interface{}{
+				"internal": int(p.ContainerPort),
+				"external": int(p.HostPort),
+				"ip":       p.HostIP,
+				"protocol": p.Protocol,
+			})
+		}
+		ids = append(ids, c.ID)
+		containers = append(containers, map[string]interface{}{
+			"id":     c.ID,
+			"names":  c.Names,
+			"image":  c.Image,
+			"state":  c.State,
+			"labels": c.Labels,
+			"ports":  ports,
+		})
+	}
+
+	d.SetId(sha256Hex([]byte(strings.Join(ids, ","))))
+	d.Set("containers", containers)
+
+	return nil
+}
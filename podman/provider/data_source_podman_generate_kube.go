@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePodmanGenerateKube() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanGenerateKubeRead,
+
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:        schema.TypeList,
+				Description: "Names or IDs of the containers or pods to export",
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"service": {
+				Type:        schema.TypeBool,
+				Description: "Whether to include Kubernetes Service objects",
+				Optional:    true,
+				Default:     false,
+			},
+
+			"yaml": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcePodmanGenerateKubeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	options := entities.GenerateKubeOptions{
+		Service: d.Get("service").(bool),
+	}
+
+	names := stringListToStringSlice(d.Get("names").([]interface{}))
+
+	var documents []string
+	for _, name := range names {
+		document, err := podmanClient.GenerateKube(ctx, name, options)
+		if err != nil {
+			return diag.Errorf("Unable to generate kube YAML for %s: %s", name, err)
+		}
+		documents = append(documents, strings.TrimSpace(document))
+	}
+
+	yaml := strings.Join(documents, "\n---\n") + "\n"
+
+	d.SetId(strings.Join(names, ","))
+	d.Set("yaml", yaml)
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"sort"
+
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePodmanGenerateSystemd() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanGenerateSystemdRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name or ID of the container or pod",
+				Required:    true,
+			},
+
+			"use_name": {
+				Type:        schema.TypeBool,
+				Description: "Use the container or pod name instead of its ID in the units",
+				Optional:    true,
+				Default:     false,
+			},
+
+			"new": {
+				Type:        schema.TypeBool,
+				Description: "Create a new container on start instead of starting the existing one",
+				Optional:    true,
+				Default:     false,
+			},
+
+			"restart_policy": {
+				Type:             schema.TypeString,
+				Description:      "systemd restart policy",
+				Optional:         true,
+				Default:          "on-failure",
+				ValidateDiagFunc: validateStringMatchesPattern(`^(no|on-success|on-failure|on-abnormal|on-watchdog|on-abort|always)$`),
+			},
+
+			"stop_timeout": {
+				Type:             schema.TypeInt,
+				Description:      "Stop timeout in seconds",
+				Optional:         true,
+				ValidateDiagFunc: validateIntegerGeqThan(0),
+			},
+
+			"container_prefix": {
+				Type:        schema.TypeString,
+				Description: "Unit name prefix for containers",
+				Optional:    true,
+				Default:     "container",
+			},
+
+			"pod_prefix": {
+				Type:        schema.TypeString,
+				Description: "Unit name prefix for pods",
+				Optional:    true,
+				Default:     "pod",
+			},
+
+			"separator": {
+				Type:        schema.TypeString,
+				Description: "Separator between the unit name prefix and the container or pod name",
+				Optional:    true,
+				Default:     "-",
+			},
+
+			"units": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"file_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"content": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePodmanGenerateSystemdRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	name := d.Get("name").(string)
+	options := entities.GenerateSystemdOptions{
+		Name:            d.Get("use_name").(bool),
+		New:             d.Get("new").(bool),
+		RestartPolicy:   d.Get("restart_policy").(string),
+		ContainerPrefix: d.Get("container_prefix").(string),
+		PodPrefix:       d.Get("pod_prefix").(string),
+		Separator:       d.Get("separator").(string),
+	}
+	if v, ok := d.GetOkExists("stop_timeout"); ok {
+		stopTimeout := uint(v.(int))
+		options.StopTimeout = &stopTimeout
+	}
+
+	units, err := podmanClient.GenerateSystemd(ctx, name, options)
+	if err != nil {
+		return diag.Errorf("Unable to generate systemd units for %s: %s", name, err)
+	}
+
+	unitNames := make([]string, 0, len(units))
+	for unitName := range units {
+		unitNames = append(unitNames, unitName)
+	}
+	sort.Strings(unitNames)
+
+	unitList := make([]interface{}, 0, len(units))
+	for _, unitName := range unitNames {
+		unitList = append(unitList, map[string]interface{}{
+			"file_name": unitName + ".service",
+			"content":   units[unitName],
+		})
+	}
+
+	d.SetId(name)
+	d.Set("units", unitList)
+
+	return nil
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePodmanImage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanImageRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name or ID of the local image",
+				Required:    true,
+			},
+
+			"image_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"repo_digests": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"repo_tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"env": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"entrypoint": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"command": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"working_dir": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"user": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"exposed_ports": {
+				Type:        schema.TypeList,
+				Description: "Exposed ports in the form port/protocol",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"volumes": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"architecture": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"os": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"size": {
+				Type:        schema.TypeInt,
+				Description: "Size of the image in bytes",
+				Computed:    true,
+			},
+
+			"created": {
+				Type:        schema.TypeString,
+				Description: "Creation time in RFC 3339 format",
+				Computed:    true,
+			},
+
+			"history": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"created": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"created_by": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"author": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"comment": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"empty_layer": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func sortedMapKeys(m map[string]struct{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func dataSourcePodmanImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	name := d.Get("name").(string)
+	image, err := podmanClient.InspectImage(ctx, name)
+	if err != nil {
+		return diag.Errorf("Unable to inspect image %s: %s", name, err)
+	}
+
+	d.SetId(image.ID)
+	d.Set("image_id", image.ID)
+	d.Set("repo_digests", image.RepoDigests)
+	d.Set("repo_tags", image.RepoTags)
+	d.Set("labels", image.Labels)
+	d.Set("architecture", image.Architecture)
+	d.Set("os", image.Os)
+	d.Set("size", int(image.Size))
+	d.Set("created", formatTime(image.Created))
+
+	if image.Config != nil {
+		d.Set("env", image.Config.Env)
+		d.Set("entrypoint", image.Config.Entrypoint)
+		d.Set("command", image.Config.Cmd)
+		d.Set("working_dir", image.Config.WorkingDir)
+		d.Set("user", image.Config.User)
+		d.Set("exposed_ports", sortedMapKeys(image.Config.ExposedPorts))
+		d.Set("volumes", sortedMapKeys(image.Config.Volumes))
+	}
+
+	history := make([]interface{}, 0, len(image.History))
+	for _, h := range image.History {
+		history = append(history, map[string]interface{}{
+			"created":     formatTime(h.Created),
+			"created_by":  h.CreatedBy,
+			"author":      h.Author,
+			"comment":     h.Comment,
+			"empty_layer": h.EmptyLayer,
+		})
+	}
+	d.Set("history", history)
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePodmanImages() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanImagesRead,
+
+		Schema: map[string]*schema.Schema{
+			"all": {
+				Type:        schema.TypeBool,
+				Description: "Also list intermediate images",
+				Optional:    true,
+				Default:     false,
+			},
+
+			"reference": {
+				Type:        schema.TypeString,
+				Description: "Image reference the images must match, may contain wildcards, e.g. registry.example.com/app:*",
+				Optional:    true,
+			},
+
+			"label": {
+				Type:        schema.TypeList,
+				Description: "Labels the images must have, in the form key or key=value",
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"dangling": {
+				Type:             schema.TypeString,
+				Description:      "Only list untagged images if true, only tagged images if false",
+				Optional:         true,
+				ValidateDiagFunc: validateStringMatchesPattern(`^(true|false)$`),
+			},
+
+			"before": {
+				Type:        schema.TypeString,
+				Description: "Only list images created before the given image",
+				Optional:    true,
+			},
+
+			"since": {
+				Type:        schema.TypeString,
+				Description: "Only list images created after the given image",
+				Optional:    true,
+			},
+
+			"images": {
+				Type:        schema.TypeList,
+				Description: "Matching images, newest first",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"tags": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"digests": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Description: "Size of the image in bytes",
+							Computed:    true,
+						},
+						"created": {
+							Type:        schema.TypeString,
+							Description: "Creation time in RFC 3339 format",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// flattenImageSummaries converts the listed images, newest first
+func flattenImageSummaries(list []*entities.ImageSummary) []interface{} {
+	sorted := make([]*entities.ImageSummary, len(list))
+	copy(sorted, list)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Created > sorted[j].Created
+	})
+
+	images := make([]interface{}, 0, len(sorted))
+	for _, image := range sorted {
+		created := time.Unix(image.Created, 0).UTC()
+		images = append(images, map[string]interface{}{
+			"id":      image.ID,
+			"tags":    image.RepoTags,
+			"digests": image.Digests,
+			"size":    int(image.Size),
+			"created": formatTime(&created),
+		})
+	}
+	return images
+}
+
+func dataSourcePodmanImagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	filters := listFilters(d, map[string]string{
+		"reference": "reference",
+		"label":     "label",
+		"dangling":  "dangling",
+		"before":    "before",
+		"since":     "since",
+	})
+	list, err := podmanClient.ListImages(ctx, filters, d.Get("all").(bool))
+	if err != nil {
+		return diag.Errorf("Unable to list images: %s", err)
+	}
+
+	ids := make([]string, 0, len(list))
+	for _, image := range list {
+		ids = append(ids, image.ID)
+	}
+
+	d.SetId(sha256Hex([]byte(strings.Join(ids, ","))))
+	d.Set("images", flattenImageSummaries(list))
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/containers/podman/v2/pkg/domain/entities"
+)
+
+func TestFlattenImageSummaries(t *testing.T) {
+	images := flattenImageSummaries([]*entities.ImageSummary{
+		{ID: "old", RepoTags: []string{"app:1"}, Created: 1600000000},
+		{ID: "new", RepoTags: []string{"app:2"}, Created: 1600000100, Size: 42},
+	})
+
+	if len(images) != 2 {
+		t.Fatalf("expected 2 images, got %d", len(images))
+	}
+	newest := images[0].(map[string]interface{})
+	if newest["id"] != "new" || newest["size"] != 42 {
+		t.Fatalf("unexpected newest image %v", newest)
+	}
+	if newest["created"] != "2020-09-13T12:28:20Z" {
+		t.Fatalf("unexpected created time %v", newest["created"])
+	}
+}
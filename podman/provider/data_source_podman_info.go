@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePodmanInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanInfoRead,
+
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"api_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"rootless": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"cgroup_version": {
+				Type:        schema.TypeString,
+				Description: "v1 or v2",
+				Computed:    true,
+			},
+
+			"cgroup_manager": {
+				Type:        schema.TypeString,
+				Description: "systemd or cgroupfs",
+				Computed:    true,
+			},
+
+			"storage_driver": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"graph_root": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"oci_runtime": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"network_backend": {
+				Type:        schema.TypeString,
+				Description: "cni or netavark",
+				Computed:    true,
+			},
+
+			"cpus": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+
+			"memory_total": {
+				Type:        schema.TypeInt,
+				Description: "Total memory of the host in bytes",
+				Computed:    true,
+			},
+
+			"registries_search": {
+				Type:        schema.TypeList,
+				Description: "Registries searched for unqualified image names",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourcePodmanInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	info, err := podmanClient.Info(ctx)
+	if err != nil {
+		return diag.Errorf("Unable to get system info: %s", err)
+	}
+
+	d.Set("version", info.Version.Version)
+	d.Set("api_version", info.Version.APIVersion)
+	d.Set("network_backend", info.NetworkBackend)
+
+	hostname := "localhost"
+	if info.Host != nil {
+		if info.Host.Hostname != "" {
+			hostname = info.Host.Hostname
+		}
+		d.Set("rootless", info.Host.Rootless)
+		d.Set("cgroup_version", info.Host.CGroupsVersion)
+		d.Set("cgroup_manager", info.Host.CgroupManager)
+		d.Set("cpus", info.Host.CPUs)
+		d.Set("memory_total", int(info.Host.MemTotal))
+		if info.Host.OCIRuntime != nil {
+			d.Set("oci_runtime", info.Host.OCIRuntime.Name)
+		}
+	}
+	if info.Store != nil {
+		d.Set("storage_driver", info.Store.GraphDriverName)
+		d.Set("graph_root", info.Store.GraphRoot)
+	}
+
+	search := make([]string, 0)
+	if registries, ok := info.Registries["search"].([]interface{}); ok {
+		for _, r := range registries {
+			if r, ok := r.(string); ok {
+				search = append(search, r)
+			}
+		}
+	}
+	d.Set("registries_search", search)
+
+	d.SetId(hostname)
+
+	return nil
+}
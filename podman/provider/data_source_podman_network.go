@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePodmanNetwork() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanNetworkRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"driver": {
+				Type:        schema.TypeString,
+				Description: "Type of the main CNI plugin, e.g. bridge or macvlan",
+				Computed:    true,
+			},
+
+			"labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"ipam_config": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"subnet": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"gateway": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// cniNetworkConfig is the subset of a CNI configuration list the network data source exposes
+type cniNetworkConfig struct {
+	Name string `json:"name"`
+	Args struct {
+		PodmanLabels map[string]string `json:"podman_labels"`
+	} `json:"args"`
+	Plugins []struct {
+		Type string `json:"type"`
+		IPAM struct {
+			Subnet  string `json:"subnet"`
+			Gateway string `json:"gateway"`
+			Ranges  [][]struct {
+				Subnet  string `json:"subnet"`
+				Gateway string `json:"gateway"`
+			} `json:"ranges"`
+		} `json:"ipam"`
+	} `json:"plugins"`
+}
+
+func parseCNINetworkConfig(report map[string]interface{}) (*cniNetworkConfig, error) {
+	raw, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	config := &cniNetworkConfig{}
+	if err := json.Unmarshal(raw, config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+func (c *cniNetworkConfig) driver() string {
+	if len(c.Plugins) == 0 {
+		return ""
+	}
+	return c.Plugins[0].Type
+}
+
+func (c *cniNetworkConfig) ipamConfig() []interface{} {
+	ipam := make([]interface{}, 0)
+	for _, plugin := range c.Plugins {
+		if plugin.IPAM.Subnet != "" {
+			ipam = append(ipam, map[string]interface{}{
+				"subnet":  plugin.IPAM.Subnet,
+				"gateway": plugin.IPAM.Gateway,
+			})
+		}
+		for _, rangeSet := range plugin.IPAM.Ranges {
+			for _, r := range rangeSet {
+				ipam = append(ipam, map[string]interface{}{
+					"subnet":  r.Subnet,
+					"gateway": r.Gateway,
+				})
+			}
+		}
+	}
+	return ipam
+}
+
+func dataSourcePodmanNetworkRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	name := d.Get("name").(string)
+	report, err := podmanClient.InspectNetwork(ctx, name)
+	if err != nil {
+		return diag.Errorf("Unable to inspect network %s: %s", name, err)
+	}
+
+	config, err := parseCNINetworkConfig(report)
+	if err != nil {
+		return diag.Errorf("Unable to parse configuration of network %s: %s", name, err)
+	}
+
+	d.SetId(config.Name)
+	d.Set("driver", config.driver())
+	d.Set("labels", config.Args.PodmanLabels)
+	d.Set("ipam_config", config.ipamConfig())
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestParseCNINetworkConfig(t *testing.T) {
+	var report map[string]interface{}
+	err := json.Unmarshal([]byte(`{
+		"cniVersion": "0.4.0",
+		"name": "shared",
+		"args": {"podman_labels": {"team": "infra"}},
+		"plugins": [
+			{
+				"type": "bridge",
+				"bridge": "cni-podman1",
+				"ipam": {
+					"type": "host-local",
+					"ranges": [[{"subnet": "10.89.0.0/24", "gateway": "10.89.0.1"}]]
+				}
+			},
+			{"type": "portmap"},
+			{"type": "firewall"}
+		]
+	}`), &report)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	config, err := parseCNINetworkConfig(report)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if config.Name != "shared" || config.driver() != "bridge" {
+		t.Fatalf("unexpected name %q or driver %q", config.Name, config.driver())
+	}
+	if config.Args.PodmanLabels["team"] != "infra" {
+		t.Fatalf("unexpected labels %v", config.Args.PodmanLabels)
+	}
+	expected := []interface{}{
+		map[string]interface{}{"subnet": "10.89.0.0/24", "gateway": "10.89.0.1"},
+	}
+	if ipam := config.ipamConfig(); !reflect.DeepEqual(ipam, expected) {
+		t.Fatalf("unexpected ipam config %v", ipam)
+	}
+}
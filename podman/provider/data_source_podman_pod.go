@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePodmanPod() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanPodRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"infra_container_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"hostname": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"shared_namespaces": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"containers": {
+				Type:        schema.TypeList,
+				Description: "Containers in the pod, including the infra container",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePodmanPodRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	name := d.Get("name").(string)
+	pod, err := podmanClient.InspectPod(ctx, name)
+	if err != nil {
+		return diag.Errorf("Unable to inspect pod %s: %s", name, err)
+	}
+
+	d.SetId(pod.ID)
+	d.Set("infra_container_id", pod.InfraContainerID)
+	d.Set("state", pod.State)
+	d.Set("hostname", pod.Hostname)
+	d.Set("labels", pod.Labels)
+	d.Set("shared_namespaces", pod.SharedNamespaces)
+
+	containers := make([]interface{}, 0, len(pod.Containers))
+	for _, c := range pod.Containers {
+		containers = append(containers, map[string]interface{}{
+			"id":    c.ID,
+			"name":  c.Name,
+			"state": c.State,
+		})
+	}
+	d.Set("containers", containers)
+
+	return nil
+}
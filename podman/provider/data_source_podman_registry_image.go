@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/containers/image/v5/docker/reference"
+	"github.com/containers/image/v5/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/saitho/terraform-provider-podman/podman/client"
+)
+
+func dataSourcePodmanRegistryImage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanRegistryImageRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Image reference in the registry, e.g. registry.example.com/app:1.0",
+				Required:    true,
+			},
+
+			"tls_verify": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"digest": {
+				Type:        schema.TypeString,
+				Description: "Digest of the manifest or manifest list the tag points to",
+				Computed:    true,
+			},
+
+			"image_with_digest": {
+				Type:        schema.TypeString,
+				Description: "Image reference pinned to the digest, suitable for podman_container.image",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func dataSourcePodmanRegistryImageRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*ProviderConfig)
+
+	name := d.Get("name").(string)
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		return diag.Errorf("Invalid image name %s: %s", name, err)
+	}
+	named = reference.TagNameOnly(named)
+
+	sys := &types.SystemContext{
+		DockerAuthConfig:            config.authForImage(name),
+		DockerInsecureSkipTLSVerify: types.NewOptionalBool(!d.Get("tls_verify").(bool)),
+	}
+	imageDigest, err := client.ImageDigest(ctx, "docker://"+named.String(), sys)
+	if err != nil {
+		return diag.Errorf("Unable to get digest of %s: %s", name, err)
+	}
+
+	d.SetId(imageDigest.String())
+	d.Set("digest", imageDigest.String())
+	d.Set("image_with_digest", reference.TrimNamed(named).String()+"@"+imageDigest.String())
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourcePodmanVolume() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcePodmanVolumeRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"driver": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"driver_options": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"labels": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"mountpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourcePodmanVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	name := d.Get("name").(string)
+	volume, err := podmanClient.InspectVolume(ctx, name)
+	if err != nil {
+		return diag.Errorf("Unable to inspect volume %s: %s", name, err)
+	}
+
+	d.SetId(volume.Name)
+	d.Set("driver", volume.Driver)
+	d.Set("driver_options", volume.Options)
+	d.Set("labels", volume.Labels)
+	d.Set("mountpoint", volume.Mountpoint)
+
+	return nil
+}
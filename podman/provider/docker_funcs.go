@@ -2,6 +2,8 @@ package provider
 
 import (
 	"errors"
+	"fmt"
+	"github.com/containers/image/v5/docker/reference"
 	"github.com/containers/podman/v2/pkg/specgen"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"net"
@@ -37,13 +39,13 @@ func portSetToPodmanPortMappings(ports []interface{}) []specgen.PortMapping {
 		port := portInt.(map[string]interface{})
 		portMapping := specgen.PortMapping{}
 		portMapping.Protocol = port["protocol"].(string)
-		internal, intOk := port["internal"].(uint16)
+		internal, intOk := port["internal"].(int)
 		if intOk {
-			portMapping.ContainerPort = internal
+			portMapping.ContainerPort = uint16(internal)
 		}
-		external, extOk := port["external"].(uint16)
+		external, extOk := port["external"].(int)
 		if extOk {
-			portMapping.HostPort = external
+			portMapping.HostPort = uint16(external)
 		}
 
 		ip, ipOk := port["ip"].(string)
@@ -111,7 +113,28 @@ func stringSetToDNSServers(stringSet *schema.Set) []net.IP {
 		return ret
 	}
 	for _, envVal := range stringSet.List() {
-		ret = append(ret, envVal.(net.IP))
+		ret = append(ret, net.ParseIP(envVal.(string)))
 	}
 	return ret
 }
+
+// splitImageTag splits an image reference into repository and tag, defaulting to the latest tag.
+// References pinned to a digest are rejected, a new image cannot be stored under an existing digest.
+func splitImageTag(image string) (string, string, error) {
+	ref, err := reference.Parse(image)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid image name %q: %s", image, err)
+	}
+	named, ok := ref.(reference.Named)
+	if !ok {
+		return "", "", fmt.Errorf("image name %q has no repository", image)
+	}
+	if _, ok := ref.(reference.Digested); ok {
+		return "", "", fmt.Errorf("image name %q must not contain a digest", image)
+	}
+	tag := "latest"
+	if tagged, ok := ref.(reference.Tagged); ok {
+		tag = tagged.Tag()
+	}
+	return named.Name(), tag, nil
+}
@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/containers/podman/v2/pkg/specgen"
+)
+
+func TestPortSetToPodmanPortMappings(t *testing.T) {
+	ports := []interface{}{
+		map[string]interface{}{
+			"internal": 80,
+			"external": 8080,
+			"ip":       "0.0.0.0",
+			"protocol": "tcp",
+		},
+	}
+
+	expected := []specgen.PortMapping{{
+		ContainerPort: 80,
+		HostPort:      8080,
+		HostIP:        "0.0.0.0",
+		Protocol:      "tcp",
+	}}
+	if got := portSetToPodmanPortMappings(ports); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestSplitImageTag(t *testing.T) {
+	cases := map[string][2]string{
+		"golden":                          {"golden", "latest"},
+		"golden:v1":                       {"golden", "v1"},
+		"localhost:5000/lab/golden":       {"localhost:5000/lab/golden", "latest"},
+		"localhost:5000/lab/golden:2020a": {"localhost:5000/lab/golden", "2020a"},
+	}
+
+	for image, expected := range cases {
+		repo, tag, err := splitImageTag(image)
+		if err != nil {
+			t.Fatalf("%s: %s", image, err)
+		}
+		if repo != expected[0] || tag != expected[1] {
+			t.Fatalf("%s: expected %v, got [%s %s]", image, expected, repo, tag)
+		}
+	}
+
+	for _, image := range []string{
+		"golden@sha256:0123456789012345678901234567890123456789012345678901234567890123",
+		"golden:v1@sha256:0123456789012345678901234567890123456789012345678901234567890123",
+		"Golden",
+	} {
+		if _, _, err := splitImageTag(image); err == nil {
+			t.Fatalf("%s: expected an error", image)
+		}
+	}
+}
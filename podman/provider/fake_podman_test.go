@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakePodman is a Podman service on a unix socket answering with the handlers of its routes,
+// keyed by method and API path without the version prefix, e.g. "GET /containers/json"
+type fakePodman struct {
+	t       *testing.T
+	version string
+	routes  map[string]http.HandlerFunc
+	dir     string
+	server  *http.Server
+	oldHost *string
+}
+
+// newFakePodman starts the service and points CONTAINER_HOST at it
+func newFakePodman(t *testing.T, version string, routes map[string]http.HandlerFunc) *fakePodman {
+	dir, err := ioutil.TempDir("", "fake-podman")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	listener, err := net.Listen("unix", filepath.Join(dir, "podman.sock"))
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	f := &fakePodman{t: t, version: version, routes: routes, dir: dir}
+	f.server = &http.Server{Handler: f}
+	go f.server.Serve(listener)
+
+	if v, ok := os.LookupEnv("CONTAINER_HOST"); ok {
+		f.oldHost = &v
+	}
+	os.Setenv("CONTAINER_HOST", "unix://"+listener.Addr().String())
+	return f
+}
+
+func (f *fakePodman) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := r.URL.Path
+	if strings.HasSuffix(path, "/_ping") {
+		// The bindings ping at a path relative to the API prefix
+		path = "/_ping"
+	} else if i := strings.Index(path, "/libpod/"); i >= 0 {
+		path = path[i+len("/libpod"):]
+	}
+	route := r.Method + " " + path
+	if handler, ok := f.routes[route]; ok {
+		handler(w, r)
+		return
+	}
+	switch route {
+	case "GET /_ping":
+		w.WriteHeader(http.StatusOK)
+	case "GET /version":
+		fmt.Fprintf(w, `{"Version":%q}`, f.version)
+	default:
+		f.t.Errorf("unexpected request %s", route)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"cause":"no such route","message":"%s","response":404}`, route)
+	}
+}
+
+func (f *fakePodman) Close() {
+	f.server.Close()
+	os.RemoveAll(f.dir)
+	if f.oldHost != nil {
+		os.Setenv("CONTAINER_HOST", *f.oldHost)
+	} else {
+		os.Unsetenv("CONTAINER_HOST")
+	}
+}
+
+// respondJSON returns a handler answering with the given status and body
+func respondJSON(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		fmt.Fprint(w, body)
+	}
+}
@@ -0,0 +1,20 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/saitho/terraform-provider-podman/podman/client"
+)
+
+// requirePodmanFeature fails if the Podman service lacks a feature needed by the given attribute
+func requirePodmanFeature(ctx context.Context, meta interface{}, attribute string, feature client.Feature) error {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return err
+	}
+	if err := podmanClient.RequireFeature(feature); err != nil {
+		return fmt.Errorf("%s: %s", attribute, err)
+	}
+	return nil
+}
@@ -31,3 +31,14 @@ func labelSetToMap(labels *schema.Set) map[string]string {
 	}
 	return mapped
 }
+
+func mapToLabelSet(labels map[string]string) []interface{} {
+	var mapped []interface{}
+	for k, v := range labels {
+		mapped = append(mapped, map[string]interface{}{
+			"label": k,
+			"value": v,
+		})
+	}
+	return mapped
+}
@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMapToLabelSet(t *testing.T) {
+	labels := map[string]string{
+		"com.example.team": "storage",
+		"backup":           "daily",
+	}
+
+	set := schema.NewSet(schema.HashResource(labelSchema), mapToLabelSet(labels))
+	if set.Len() != len(labels) {
+		t.Fatalf("expected %d labels, got %d", len(labels), set.Len())
+	}
+	if got := labelSetToMap(set); !reflect.DeepEqual(got, labels) {
+		t.Fatalf("expected %v, got %v", labels, got)
+	}
+}
@@ -0,0 +1,24 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// listFilters builds the filters of a Podman list request from the given attributes.
+// String attributes become a single filter value, list attributes one value per element.
+func listFilters(d *schema.ResourceData, keys map[string]string) map[string][]string {
+	filters := map[string][]string{}
+	for attribute, filter := range keys {
+		switch v := d.Get(attribute).(type) {
+		case string:
+			if v != "" {
+				filters[filter] = append(filters[filter], v)
+			}
+		case []interface{}:
+			for _, value := range v {
+				filters[filter] = append(filters[filter], value.(string))
+			}
+		}
+	}
+	return filters
+}
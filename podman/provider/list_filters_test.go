@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestListFilters(t *testing.T) {
+	d := dataSourcePodmanContainers().TestResourceData()
+	d.Set("label", []interface{}{"app=web", "tier"})
+	d.Set("name", "^web-")
+	d.Set("status", []interface{}{"running"})
+
+	filters := listFilters(d, map[string]string{
+		"label":    "label",
+		"name":     "name",
+		"status":   "status",
+		"ancestor": "ancestor",
+	})
+	expected := map[string][]string{
+		"label":  {"app=web", "tier"},
+		"name":   {"^web-"},
+		"status": {"running"},
+	}
+	if !reflect.DeepEqual(filters, expected) {
+		t.Fatalf("unexpected filters %v", filters)
+	}
+}
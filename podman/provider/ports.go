@@ -0,0 +1,42 @@
+package provider
+
+import "github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+var portSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"internal": {
+			Type:     schema.TypeInt,
+			Required: true,
+			ForceNew: true,
+		},
+
+		"external": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
+		},
+
+		"ip": {
+			Type:     schema.TypeString,
+			Default:  "0.0.0.0",
+			Optional: true,
+			ForceNew: true,
+			StateFunc: func(val interface{}) string {
+				// Empty IP assignments default to 0.0.0.0
+				if val.(string) == "" {
+					return "0.0.0.0"
+				}
+
+				return val.(string)
+			},
+		},
+
+		"protocol": {
+			Type:     schema.TypeString,
+			Default:  "tcp",
+			Optional: true,
+			ForceNew: true,
+		},
+	},
+}
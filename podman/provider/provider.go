@@ -7,6 +7,75 @@ import (
 func New() *schema.Provider {
 	return &schema.Provider{
 		Schema: map[string]*schema.Schema{
+			"connection": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CONTAINER_CONNECTION", ""),
+				Description: "Name of a connection added with `podman system connection add`",
+			},
+
+			"ca_material": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_file"},
+				Description:   "PEM encoded CA certificate to verify tcp:// endpoints with",
+			},
+
+			"cert_material": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"cert_file"},
+				Description:   "PEM encoded client certificate for tcp:// endpoints",
+			},
+
+			"key_material": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				ConflictsWith: []string{"key_file"},
+				Description:   "PEM encoded private key of the client certificate",
+			},
+
+			"ca_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM encoded CA certificate",
+			},
+
+			"cert_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to a PEM encoded client certificate",
+			},
+
+			"key_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to the PEM encoded private key of the client certificate",
+			},
+
+			"tls_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Connect to tcp:// endpoints with TLS and verify their certificate, defaults to true once TLS is used",
+			},
+
+			"max_retries": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				Default:          3,
+				ValidateDiagFunc: validateIntegerGeqThan(0),
+				Description:      "Number of retries of transient Podman API failures, 0 disables retries",
+			},
+
+			"retry_max_wait": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "30s",
+				ValidateDiagFunc: validateDurationGt0(),
+				Description:      "Maximum wait between retries, e.g. 30s",
+			},
+
 			"registry_auth": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -37,10 +106,33 @@ func New() *schema.Provider {
 			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"podman_container": resourcePodmanContainer(),
+			"podman_container":        resourcePodmanContainer(),
+			"podman_container_commit": resourcePodmanContainerCommit(),
+			"podman_container_exec":   resourcePodmanContainerExec(),
+			"podman_image_archive":    resourcePodmanImageArchive(),
+			"podman_image_copy":       resourcePodmanImageCopy(),
+			"podman_image_load":       resourcePodmanImageLoad(),
+			"podman_manifest":         resourcePodmanManifest(),
+			"podman_play_kube":        resourcePodmanPlayKube(),
+			"podman_pod":              resourcePodmanPod(),
+			"podman_quadlet":          resourcePodmanQuadlet(),
+			"podman_volume":           resourcePodmanVolume(),
 			// "podman_image":     resourcePodmanImage(),
 			// "podman_network":   resourcePodmanNetwork(),
-			// "podman_volume":    resourcePodmanVolume(),
 		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"podman_container_file":   dataSourcePodmanContainerFile(),
+			"podman_containers":       dataSourcePodmanContainers(),
+			"podman_generate_kube":    dataSourcePodmanGenerateKube(),
+			"podman_generate_systemd": dataSourcePodmanGenerateSystemd(),
+			"podman_image":            dataSourcePodmanImage(),
+			"podman_images":           dataSourcePodmanImages(),
+			"podman_info":             dataSourcePodmanInfo(),
+			"podman_network":          dataSourcePodmanNetwork(),
+			"podman_pod":              dataSourcePodmanPod(),
+			"podman_registry_image":   dataSourcePodmanRegistryImage(),
+			"podman_volume":           dataSourcePodmanVolume(),
+		},
+		ConfigureFunc: providerConfigure,
 	}
 }
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// quadletKinds lists the supported Quadlet unit types, each of which is a block on podman_quadlet
+var quadletKinds = []string{"container", "pod", "network"}
+
+type quadletEntry struct {
+	key   string
+	value string
+}
+
+type quadletSection struct {
+	name    string
+	entries []quadletEntry
+}
+
+func (s *quadletSection) add(key, value string) {
+	if value == "" {
+		return
+	}
+	s.entries = append(s.entries, quadletEntry{key: key, value: value})
+}
+
+func (s *quadletSection) addAll(key string, values []string) {
+	for _, value := range values {
+		s.add(key, value)
+	}
+}
+
+type quadletUnit struct {
+	sections []*quadletSection
+}
+
+func (u *quadletUnit) section(name string) *quadletSection {
+	for _, s := range u.sections {
+		if s.name == name {
+			return s
+		}
+	}
+	s := &quadletSection{name: name}
+	u.sections = append(u.sections, s)
+	return s
+}
+
+func (u *quadletUnit) String() string {
+	var b strings.Builder
+	b.WriteString("# Generated by terraform-provider-podman, do not edit\n")
+	for _, s := range u.sections {
+		if len(s.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n[%s]\n", s.name)
+		for _, e := range s.entries {
+			fmt.Fprintf(&b, "%s=%s\n", e.key, e.value)
+		}
+	}
+	return b.String()
+}
+
+// quadletQuote joins arguments the way systemd splits command lines
+func quadletQuote(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg != "" && !strings.ContainsAny(arg, " \t\n\"'\\") {
+			quoted = append(quoted, arg)
+			continue
+		}
+		escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(arg)
+		quoted = append(quoted, `"`+escaped+`"`)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func sortedKeyValues(m map[string]string) []string {
+	ret := make([]string, 0, len(m))
+	for k, v := range m {
+		ret = append(ret, quadletQuote([]string{k + "=" + v}))
+	}
+	sort.Strings(ret)
+	return ret
+}
+
+func sortedStringSet(set *schema.Set) []string {
+	ret := stringSetToStringSlice(set)
+	sort.Strings(ret)
+	return ret
+}
+
+func quadletPublishPorts(ports []interface{}) []string {
+	var ret []string
+	for _, portMapping := range portSetToPodmanPortMappings(ports) {
+		external := ""
+		if portMapping.HostPort != 0 {
+			external = fmt.Sprint(portMapping.HostPort)
+		}
+		ret = append(ret, fmt.Sprintf("%s:%s:%d/%s", portMapping.HostIP, external, portMapping.ContainerPort, portMapping.Protocol))
+	}
+	return ret
+}
+
+// quadletHealthCmd converts the Docker style healthcheck test into the --health-cmd value:
+// exec form becomes a JSON array, CMD-SHELL the plain shell command
+func quadletHealthCmd(test []string) string {
+	if len(test) == 0 {
+		return ""
+	}
+	switch test[0] {
+	case "NONE":
+		return "none"
+	case "CMD-SHELL":
+		return strings.Join(test[1:], " ")
+	case "CMD":
+		test = test[1:]
+	}
+	cmd, _ := json.Marshal(test)
+	return string(cmd)
+}
+
+func quadletRestartPolicy(restart string) string {
+	switch restart {
+	case "on-failure":
+		return "on-failure"
+	case "always", "unless-stopped":
+		return "always"
+	}
+	return ""
+}
+
+// renderQuadlet renders the unit file for the given kind from the raw block of podman_quadlet
+func renderQuadlet(kind, description string, wantedBy []string, raw map[string]interface{}) (string, error) {
+	unit := &quadletUnit{}
+	unit.section("Unit").add("Description", description)
+
+	switch kind {
+	case "container":
+		renderQuadletContainer(unit, raw)
+	case "pod":
+		renderQuadletPod(unit, raw)
+	case "network":
+		renderQuadletNetwork(unit, raw)
+	default:
+		return "", fmt.Errorf("unsupported quadlet kind %q", kind)
+	}
+
+	unit.section("Install").addAll("WantedBy", wantedBy)
+	return unit.String(), nil
+}
+
+func renderQuadletContainer(unit *quadletUnit, raw map[string]interface{}) {
+	s := unit.section("Container")
+	s.add("Image", raw["image"].(string))
+	s.add("ContainerName", raw["name"].(string))
+	if v := raw["pod"].(string); v != "" {
+		s.add("Pod", v+".pod")
+	}
+	if v := stringListToStringSlice(raw["entrypoint"].([]interface{})); len(v) > 0 {
+		s.add("Entrypoint", quadletQuote(v))
+	}
+	if v := stringListToStringSlice(raw["command"].([]interface{})); len(v) > 0 {
+		s.add("Exec", quadletQuote(v))
+	}
+	s.add("WorkingDir", raw["working_dir"].(string))
+	s.add("User", raw["user"].(string))
+	for _, env := range sortedStringSet(raw["env"].(*schema.Set)) {
+		s.add("Environment", quadletQuote([]string{env}))
+	}
+	s.addAll("Label", sortedKeyValues(labelSetToMap(raw["labels"].(*schema.Set))))
+	s.addAll("PublishPort", quadletPublishPorts(raw["ports"].([]interface{})))
+
+	for _, volumeInt := range raw["volumes"].([]interface{}) {
+		volume := volumeInt.(map[string]interface{})
+		source := volume["volume_name"].(string)
+		if source == "" {
+			source = volume["host_path"].(string)
+		}
+		// Without a source Podman creates an anonymous volume
+		value := volume["container_path"].(string)
+		if source != "" {
+			value = source + ":" + value
+		}
+		if volume["read_only"].(bool) {
+			value += ":ro"
+		}
+		s.add("Volume", value)
+	}
+
+	s.add("Network", raw["network_mode"].(string))
+	s.addAll("DNS", sortedStringSet(raw["dns"].(*schema.Set)))
+	s.addAll("DNSSearch", sortedStringSet(raw["dns_search"].(*schema.Set)))
+	s.addAll("DNSOption", sortedStringSet(raw["dns_opts"].(*schema.Set)))
+
+	for _, capInt := range raw["capabilities"].([]interface{}) {
+		capa, ok := capInt.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if v := sortedStringSet(capa["add"].(*schema.Set)); len(v) > 0 {
+			s.add("AddCapability", strings.Join(v, " "))
+		}
+		if v := sortedStringSet(capa["drop"].(*schema.Set)); len(v) > 0 {
+			s.add("DropCapability", strings.Join(v, " "))
+		}
+	}
+
+	for _, rawHealthCheck := range raw["healthcheck"].([]interface{}) {
+		rawHealthCheck := rawHealthCheck.(map[string]interface{})
+		s.add("HealthCmd", quadletHealthCmd(stringListToStringSlice(rawHealthCheck["test"].([]interface{}))))
+		for _, e := range []quadletEntry{
+			{key: "HealthInterval", value: rawHealthCheck["interval"].(string)},
+			{key: "HealthTimeout", value: rawHealthCheck["timeout"].(string)},
+			{key: "HealthStartPeriod", value: rawHealthCheck["start_period"].(string)},
+		} {
+			if e.value != "0s" {
+				s.add(e.key, e.value)
+			}
+		}
+		if v := rawHealthCheck["retries"].(int); v > 0 {
+			s.add("HealthRetries", fmt.Sprint(v))
+		}
+	}
+
+	s.add("LogDriver", raw["log_driver"].(string))
+	if raw["privileged"].(bool) {
+		s.add("PodmanArgs", "--privileged")
+	}
+
+	unit.section("Service").add("Restart", quadletRestartPolicy(raw["restart"].(string)))
+}
+
+func renderQuadletPod(unit *quadletUnit, raw map[string]interface{}) {
+	s := unit.section("Pod")
+	s.add("PodName", raw["name"].(string))
+	s.addAll("PublishPort", quadletPublishPorts(raw["ports"].([]interface{})))
+	s.add("Network", raw["network_mode"].(string))
+	s.addAll("Label", sortedKeyValues(labelSetToMap(raw["labels"].(*schema.Set))))
+}
+
+func renderQuadletNetwork(unit *quadletUnit, raw map[string]interface{}) {
+	s := unit.section("Network")
+	s.add("NetworkName", raw["name"].(string))
+	s.add("Driver", raw["driver"].(string))
+	s.addAll("Subnet", stringListToStringSlice(raw["subnets"].([]interface{})))
+	s.addAll("Gateway", stringListToStringSlice(raw["gateways"].([]interface{})))
+	s.add("IPRange", raw["ip_range"].(string))
+	if raw["ipv6"].(bool) {
+		s.add("IPv6", "true")
+	}
+	if raw["internal"].(bool) {
+		s.add("Internal", "true")
+	}
+	s.addAll("Label", sortedKeyValues(labelSetToMap(raw["labels"].(*schema.Set))))
+	s.addAll("Options", sortedKeyValues(mapTypeMapValsToString(raw["options"].(map[string]interface{}))))
+}
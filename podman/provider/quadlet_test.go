@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestRenderQuadletContainer(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePodmanQuadlet().Schema, map[string]interface{}{
+		"name":        "web",
+		"directory":   "/etc/containers/systemd",
+		"description": "Web server",
+		"wanted_by":   []interface{}{"default.target"},
+		"container": []interface{}{
+			map[string]interface{}{
+				"image":   "docker.io/library/nginx:1.19",
+				"name":    "web",
+				"command": []interface{}{"nginx", "-g", "daemon off;"},
+				"env":     []interface{}{"B=2", "A=1"},
+				"ports": []interface{}{
+					map[string]interface{}{
+						"internal": 80,
+						"external": 8080,
+					},
+				},
+				"volumes": []interface{}{
+					map[string]interface{}{
+						"volume_name":    "web-data",
+						"container_path": "/usr/share/nginx/html",
+						"read_only":      true,
+					},
+					map[string]interface{}{
+						"container_path": "/var/cache/nginx",
+					},
+				},
+				"healthcheck": []interface{}{
+					map[string]interface{}{
+						"test": []interface{}{"CMD", "curl", "-f", "http://localhost/"},
+					},
+				},
+				"restart": "unless-stopped",
+			},
+		},
+	})
+
+	fileName, content, err := renderQuadletFromSchema(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	if fileName != "/etc/containers/systemd/web.container" {
+		t.Fatalf("unexpected file name %q", fileName)
+	}
+
+	expected := `# Generated by terraform-provider-podman, do not edit
+
+[Unit]
+Description=Web server
+
+[Container]
+Image=docker.io/library/nginx:1.19
+ContainerName=web
+Exec=nginx -g "daemon off;"
+Environment=A=1
+Environment=B=2
+PublishPort=0.0.0.0:8080:80/tcp
+Volume=web-data:/usr/share/nginx/html:ro
+Volume=/var/cache/nginx
+HealthCmd=["curl","-f","http://localhost/"]
+
+[Service]
+Restart=always
+
+[Install]
+WantedBy=default.target
+`
+	if content != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, content)
+	}
+}
+
+func TestRenderQuadletNetwork(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourcePodmanQuadlet().Schema, map[string]interface{}{
+		"name":      "backend",
+		"directory": "/etc/containers/systemd",
+		"network": []interface{}{
+			map[string]interface{}{
+				"subnets":  []interface{}{"10.89.0.0/24"},
+				"gateways": []interface{}{"10.89.0.1"},
+				"internal": true,
+			},
+		},
+	})
+
+	_, content, err := renderQuadletFromSchema(d)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	expected := `# Generated by terraform-provider-podman, do not edit
+
+[Network]
+Subnet=10.89.0.0/24
+Gateway=10.89.0.1
+Internal=true
+`
+	if content != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, content)
+	}
+}
+
+func TestQuadletHealthCmd(t *testing.T) {
+	cases := []struct {
+		test     []string
+		expected string
+	}{
+		{[]string{"CMD", "curl", "-f", "http://localhost/"}, `["curl","-f","http://localhost/"]`},
+		{[]string{"CMD-SHELL", "curl -f http://localhost/ || exit 1"}, "curl -f http://localhost/ || exit 1"},
+		{[]string{"NONE"}, "none"},
+		{[]string{"/healthcheck"}, `["/healthcheck"]`},
+	}
+	for _, c := range cases {
+		if got := quadletHealthCmd(c.test); got != c.expected {
+			t.Errorf("quadletHealthCmd(%q) = %q, expected %q", c.test, got, c.expected)
+		}
+	}
+}
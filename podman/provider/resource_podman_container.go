@@ -1,14 +1,20 @@
 package provider
 
 import (
+	"archive/tar"
 	"bytes"
-	"errors"
+	"context"
+	"encoding/base64"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/containers/image/v5/manifest"
 	"github.com/containers/podman/v2/pkg/specgen"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 
@@ -17,10 +23,18 @@ import (
 
 func resourcePodmanContainer() *schema.Resource {
 	return &schema.Resource{
-		Create: resourcePodmanContainerCreate,
-		Read:   resourcePodmanContainerRead,
-		Update: resourcePodmanContainerUpdate,
-		Delete: resourcePodmanContainerDelete,
+		CreateContext: resourcePodmanContainerCreate,
+		ReadContext:   resourcePodmanContainerRead,
+		UpdateContext: resourcePodmanContainerUpdate,
+		DeleteContext: resourcePodmanContainerDelete,
+
+		CustomizeDiff: resourcePodmanContainerCustomizeDiff,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -39,6 +53,13 @@ func resourcePodmanContainer() *schema.Resource {
 				// DiffSuppressFunc: suppressIfSHAwasAdded(), // TODO mvogel
 			},
 
+			"pod": {
+				Type:        schema.TypeString,
+				Description: "Name or ID of the pod the container joins",
+				Optional:    true,
+				ForceNew:    true,
+			},
+
 			"working_dir": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -357,52 +378,32 @@ func resourcePodmanContainer() *schema.Resource {
 			},
 
 			"max_retry_count": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ValidateDiagFunc: validateIntegerGeqThan(0),
+			},
+
+			"rm": {
+				Type:        schema.TypeBool,
+				Description: "Whether to remove the container after it exits",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+
+			"read_only": {
+				Type:        schema.TypeBool,
+				Description: "Whether to mount the root filesystem of the container as read-only",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
 			},
 
 			"ports": {
 				Type:     schema.TypeList,
 				Optional: true,
 				ForceNew: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"internal": {
-							Type:     schema.TypeInt,
-							Required: true,
-							ForceNew: true,
-						},
-
-						"external": {
-							Type:     schema.TypeInt,
-							Optional: true,
-							Computed: true,
-							ForceNew: true,
-						},
-
-						"ip": {
-							Type:     schema.TypeString,
-							Default:  "0.0.0.0",
-							Optional: true,
-							ForceNew: true,
-							StateFunc: func(val interface{}) string {
-								// Empty IP assignments default to 0.0.0.0
-								if val.(string) == "" {
-									return "0.0.0.0"
-								}
-
-								return val.(string)
-							},
-						},
-
-						"protocol": {
-							Type:     schema.TypeString,
-							Default:  "tcp",
-							Optional: true,
-							ForceNew: true,
-						},
-					},
-				},
+				Elem:     portSchema,
 			},
 
 			"shm_size": {
@@ -499,6 +500,92 @@ func resourcePodmanContainer() *schema.Resource {
 					},
 				},
 			},
+
+			"start": {
+				Type:        schema.TypeBool,
+				Description: "Whether to start the container after creating it",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+
+			"attach": {
+				Type:        schema.TypeBool,
+				Description: "Whether to wait for the container to exit after starting it",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+
+			"logs": {
+				Type:        schema.TypeBool,
+				Description: "Whether to save the container logs when attached",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+
+			"container_logs": {
+				Type:        schema.TypeString,
+				Description: "The logs of the container when attach and logs are set",
+				Computed:    true,
+			},
+
+			"upload": {
+				Type:        schema.TypeSet,
+				Description: "Files to copy into the container before it is started",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"content": {
+							Type:        schema.TypeString,
+							Description: "Literal string content of the file",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"content_base64": {
+							Type:        schema.TypeString,
+							Description: "Base64-encoded content of the file, for binary data",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"source": {
+							Type:        schema.TypeString,
+							Description: "Path to a local file to upload",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"source_hash": {
+							Type:        schema.TypeString,
+							Description: "Hash of the source file, used to trigger a new container when it changes",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"file": {
+							Type:             schema.TypeString,
+							Description:      "Absolute path of the file in the container",
+							Required:         true,
+							ForceNew:         true,
+							ValidateDiagFunc: validateDockerContainerPath,
+						},
+						"executable": {
+							Type:        schema.TypeBool,
+							Description: "Whether the file is executable, ignored if permissions is set",
+							Optional:    true,
+							ForceNew:    true,
+							Default:     false,
+						},
+						"permissions": {
+							Type:             schema.TypeString,
+							Description:      "Octal file mode, e.g. 0600",
+							Optional:         true,
+							ForceNew:         true,
+							ValidateDiagFunc: validateStringMatchesPattern(`^0?[0-7]{3,4}$`),
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -516,16 +603,95 @@ func stringSliceToStringMap(stringSlice []string, separator string) map[string]s
 	return ret
 }
 
-func resourcePodmanContainerCreate(d *schema.ResourceData, meta interface{}) error {
+func resourcePodmanContainerCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	// Fail during plan rather than with an HTTP error once the container exists
+	if d.HasChange("upload") && d.Get("upload").(*schema.Set).Len() > 0 {
+		return requirePodmanFeature(ctx, meta, "upload", client.FeatureArchive)
+	}
+	return nil
+}
+
+// uploadToTar builds a tar archive holding a single file of the upload block
+func uploadToTar(upload map[string]interface{}) (io.Reader, error) {
+	content := upload["content"].(string)
+	contentBase64 := upload["content_base64"].(string)
+	source := upload["source"].(string)
+
+	testParams := []string{content, contentBase64, source}
+	setParams := 0
+	for _, v := range testParams {
+		if v != "" {
+			setParams++
+		}
+	}
+
+	if setParams == 0 {
+		return nil, fmt.Errorf("one of 'content', 'content_base64', or 'source' must be set")
+	}
+	if setParams > 1 {
+		return nil, fmt.Errorf("only one of 'content', 'content_base64', or 'source' can be set")
+	}
+
+	var contentToUpload []byte
+	if content != "" {
+		contentToUpload = []byte(content)
+	}
+	if contentBase64 != "" {
+		decoded, err := base64.StdEncoding.DecodeString(contentBase64)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode content_base64: %s", err)
+		}
+		contentToUpload = decoded
+	}
+	if source != "" {
+		sourceContent, err := ioutil.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file: %s", err)
+		}
+		contentToUpload = sourceContent
+	}
+
+	var mode int64 = 0644
+	if upload["executable"].(bool) {
+		mode = 0744
+	}
+	if permissions := upload["permissions"].(string); permissions != "" {
+		parsed, err := strconv.ParseInt(permissions, 8, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid permissions %q: %s", permissions, err)
+		}
+		mode = parsed
+	}
+
+	buf := new(bytes.Buffer)
+	tw := tar.NewWriter(buf)
+	hdr := &tar.Header{
+		Name: upload["file"].(string),
+		Mode: mode,
+		Size: int64(len(contentToUpload)),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return nil, fmt.Errorf("Error creating tar archive: %s", err)
+	}
+	if _, err := tw.Write(contentToUpload); err != nil {
+		return nil, fmt.Errorf("Error creating tar archive: %s", err)
+	}
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("Error creating tar archive: %s", err)
+	}
+	return buf, nil
+}
+
+func resourcePodmanContainerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var err error
-	podmanClient := client.Client{}
-	if err := podmanClient.Connect(); err != nil {
-		return err
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
 	}
 	image := d.Get("image").(string)
-	err = podmanClient.PullImage(image)
+	err = podmanClient.PullImage(ctx, image)
 	if err != nil {
-		return errors.New(fmt.Sprintf("Unable to create container with image %s: %s", image, err))
+		return diag.Errorf("Unable to create container with image %s: %s", image, err)
 	}
 
 	config := specgen.NewSpecGenerator(image, false)
@@ -533,7 +699,10 @@ func resourcePodmanContainerCreate(d *schema.ResourceData, meta interface{}) err
 	config.Privileged = d.Get("privileged").(bool)
 	config.PublishExposedPorts = d.Get("publish_all_ports").(bool)
 	config.RestartPolicy = d.Get("restart").(string)
-	config.RestartRetries = d.Get("max_retry_count").(*uint)
+	if v, ok := d.GetOk("max_retry_count"); ok {
+		retries := uint(v.(int))
+		config.RestartRetries = &retries
+	}
 	config.Remove = d.Get("rm").(bool)
 	config.ReadOnlyFilesystem = d.Get("read_only").(bool)
 	config.LogConfiguration = &specgen.LogConfig{
@@ -548,7 +717,7 @@ func resourcePodmanContainerCreate(d *schema.ResourceData, meta interface{}) err
 		config.Command = stringListToStringSlice(v.([]interface{}))
 		for _, v := range config.Command {
 			if v == "" {
-				return fmt.Errorf("values for command may not be empty")
+				return diag.Errorf("values for command may not be empty")
 			}
 		}
 	}
@@ -564,6 +733,9 @@ func resourcePodmanContainerCreate(d *schema.ResourceData, meta interface{}) err
 	if v, ok := d.GetOk("ports"); ok {
 		config.PortMappings = portSetToPodmanPortMappings(v.([]interface{}))
 	}
+	if v, ok := d.GetOk("pod"); ok {
+		config.Pod = v.(string)
+	}
 	if v, ok := d.GetOk("working_dir"); ok {
 		config.WorkDir = v.(string)
 	}
@@ -577,7 +749,7 @@ func resourcePodmanContainerCreate(d *schema.ResourceData, meta interface{}) err
 	if v, ok := d.GetOk("volumes"); ok {
 		volumes, volumesFrom, err = volumeSetToPodmanVolumes(v.(*schema.Set))
 		if err != nil {
-			return fmt.Errorf("Unable to parse volumes: %s", err)
+			return diag.Errorf("Unable to parse volumes: %s", err)
 		}
 	}
 	if len(volumes) != 0 {
@@ -760,8 +932,8 @@ func resourcePodmanContainerCreate(d *schema.ResourceData, meta interface{}) err
 
 	config.Name = d.Get("name").(string)
 
-	if containerId, err = podmanClient.CreateContainer(config); err != nil {
-		return fmt.Errorf("Unable to create container: %s", err)
+	if containerId, err = podmanClient.CreateContainer(ctx, config); err != nil {
+		return diag.Errorf("Unable to create container: %s", err)
 	}
 
 	d.SetId(containerId)
@@ -794,82 +966,25 @@ func resourcePodmanContainerCreate(d *schema.ResourceData, meta interface{}) err
 	//			}
 	//		}
 	//	}
-	//
-	//	if v, ok := d.GetOk("upload"); ok {
-	//
-	//		var mode int64
-	//		for _, upload := range v.(*schema.Set).List() {
-	//			content := upload.(map[string]interface{})["content"].(string)
-	//			contentBase64 := upload.(map[string]interface{})["content_base64"].(string)
-	//			source := upload.(map[string]interface{})["source"].(string)
-	//
-	//			testParams := []string{content, contentBase64, source}
-	//			setParams := 0
-	//			for _, v := range testParams {
-	//				if v != "" {
-	//					setParams++
-	//				}
-	//			}
-	//
-	//			if setParams == 0 {
-	//				return fmt.Errorf("error with upload content: one of 'content', 'content_base64', or 'source' must be set")
-	//			}
-	//			if setParams > 1 {
-	//				return fmt.Errorf("error with upload content: only one of 'content', 'content_base64', or 'source' can be set")
-	//			}
-	//
-	//			var contentToUpload string
-	//			if content != "" {
-	//				contentToUpload = content
-	//			}
-	//			if contentBase64 != "" {
-	//				decoded, _ := base64.StdEncoding.DecodeString(contentBase64)
-	//				contentToUpload = string(decoded)
-	//			}
-	//			if source != "" {
-	//				sourceContent, err := ioutil.ReadFile(source)
-	//				if err != nil {
-	//					return fmt.Errorf("could not read file: %s", err)
-	//				}
-	//				contentToUpload = string(sourceContent)
-	//			}
-	//			file := upload.(map[string]interface{})["file"].(string)
-	//			executable := upload.(map[string]interface{})["executable"].(bool)
-	//
-	//			buf := new(bytes.Buffer)
-	//			tw := tar.NewWriter(buf)
-	//			if executable {
-	//				mode = 0744
-	//			} else {
-	//				mode = 0644
-	//			}
-	//			hdr := &tar.Header{
-	//				Name: file,
-	//				Mode: mode,
-	//				Size: int64(len(contentToUpload)),
-	//			}
-	//			if err := tw.WriteHeader(hdr); err != nil {
-	//				return fmt.Errorf("Error creating tar archive: %s", err)
-	//			}
-	//			if _, err := tw.Write([]byte(contentToUpload)); err != nil {
-	//				return fmt.Errorf("Error creating tar archive: %s", err)
-	//			}
-	//			if err := tw.Close(); err != nil {
-	//				return fmt.Errorf("Error creating tar archive: %s", err)
-	//			}
-	//
-	//			dstPath := "/"
-	//			uploadContent := bytes.NewReader(buf.Bytes())
-	//			options := types.CopyToContainerOptions{}
-	//			if err := client.CopyToContainer(context.Background(), retContainer.ID, dstPath, uploadContent, options); err != nil {
-	//				return fmt.Errorf("Unable to upload volume content: %s", err)
-	//			}
-	//		}
-	//	}
+
+	if v, ok := d.GetOk("upload"); ok {
+		for _, upload := range v.(*schema.Set).List() {
+			file := upload.(map[string]interface{})["file"].(string)
+			archive, err := uploadToTar(upload.(map[string]interface{}))
+			if err != nil {
+				return diag.Errorf("error with upload content for %s: %s", file, err)
+			}
+
+			dstPath := "/"
+			if err := podmanClient.CopyToContainer(ctx, containerId, dstPath, archive); err != nil {
+				return diag.Errorf("Unable to upload %s: %s", file, err)
+			}
+		}
+	}
 
 	if d.Get("start").(bool) {
-		if err := podmanClient.StartContainer(containerId); err != nil {
-			return fmt.Errorf("Unable to start container: %s", err)
+		if err := podmanClient.StartContainer(ctx, containerId); err != nil {
+			return diag.Errorf("Unable to start container: %s", err)
 		}
 	}
 
@@ -905,8 +1020,8 @@ func resourcePodmanContainerCreate(d *schema.ResourceData, meta interface{}) err
 		//			}()
 		//		}
 
-		if err := podmanClient.WaitContainer(containerId); err != nil {
-			return fmt.Errorf("Unable to wait container end of execution: %s", err)
+		if err := podmanClient.WaitContainer(ctx, containerId); err != nil {
+			return diag.Errorf("Unable to wait container end of execution: %s", err)
 		} else {
 			if d.Get("logs").(bool) {
 				d.Set("container_logs", b.String())
@@ -914,17 +1029,17 @@ func resourcePodmanContainerCreate(d *schema.ResourceData, meta interface{}) err
 		}
 	}
 
-	return resourcePodmanContainerRead(d, meta)
+	return resourcePodmanContainerRead(ctx, d, meta)
 }
 
-func resourcePodmanContainerRead(d *schema.ResourceData, meta interface{}) error {
+func resourcePodmanContainerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
 
-func resourcePodmanContainerUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourcePodmanContainerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
 
-func resourcePodmanContainerDelete(d *schema.ResourceData, meta interface{}) error {
+func resourcePodmanContainerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return nil
 }
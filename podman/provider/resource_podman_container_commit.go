@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/containers/podman/v2/pkg/bindings/containers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/saitho/terraform-provider-podman/podman/client"
+)
+
+func resourcePodmanContainerCommit() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePodmanContainerCommitCreate,
+		ReadContext:   resourcePodmanContainerCommitRead,
+		UpdateContext: resourcePodmanContainerCommitUpdate,
+		DeleteContext: resourcePodmanContainerCommitDelete,
+
+		CustomizeDiff: resourcePodmanContainerCommitCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"container": {
+				Type:        schema.TypeString,
+				Description: "Name or ID of the container to commit",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"image": {
+				Type:             schema.TypeString,
+				Description:      "Name and tag of the new image",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateImageTag,
+			},
+
+			"author": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"message": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"changes": {
+				Type:        schema.TypeList,
+				Description: "Containerfile instructions to apply, e.g. CMD or ENV",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"pause": {
+				Type:        schema.TypeBool,
+				Description: "Pause the container while committing",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+
+			"format": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "oci",
+				ValidateDiagFunc: validateStringMatchesPattern(`^(oci|docker)$`),
+			},
+
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary map of values that, when changed, commit the container again",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"keep_locally": {
+				Type:        schema.TypeBool,
+				Description: "Keep the image in local storage on destroy",
+				Optional:    true,
+				Default:     false,
+			},
+
+			"image_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePodmanContainerCommitCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.HasChange("changes") && len(d.Get("changes").([]interface{})) > 0 {
+		return requirePodmanFeature(ctx, meta, "changes", client.FeatureCommitChanges)
+	}
+	return nil
+}
+
+func resourcePodmanContainerCommitCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	container := d.Get("container").(string)
+	repo, tag, err := splitImageTag(d.Get("image").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	author := d.Get("author").(string)
+	message := d.Get("message").(string)
+	format := d.Get("format").(string)
+	pause := d.Get("pause").(bool)
+	options := containers.CommitOptions{
+		Author:  &author,
+		Comment: &message,
+		Changes: stringListToStringSlice(d.Get("changes").([]interface{})),
+		Format:  &format,
+		Pause:   &pause,
+		Repo:    &repo,
+		Tag:     &tag,
+	}
+
+	imageId, err := podmanClient.CommitContainer(ctx, container, options)
+	if err != nil {
+		return diag.Errorf("Unable to commit container %s: %s", container, err)
+	}
+
+	d.SetId(imageId)
+
+	return resourcePodmanContainerCommitRead(ctx, d, meta)
+}
+
+func resourcePodmanContainerCommitRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	image, err := podmanClient.InspectImage(ctx, d.Id())
+	if err != nil {
+		if client.IsNotFound(err) {
+			log.Printf("[WARN] Committed image (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Unable to inspect image %s: %s", d.Id(), err)
+	}
+
+	d.Set("image_id", image.ID)
+
+	return nil
+}
+
+func resourcePodmanContainerCommitUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only keep_locally can change in place, and it is only used on destroy
+	return resourcePodmanContainerCommitRead(ctx, d, meta)
+}
+
+func resourcePodmanContainerCommitDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("keep_locally").(bool) {
+		d.SetId("")
+		return nil
+	}
+
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	if err := podmanClient.RemoveImage(ctx, d.Id()); err != nil && !client.IsNotFound(err) {
+		return diag.Errorf("Unable to remove image %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
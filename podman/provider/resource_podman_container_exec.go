@@ -0,0 +1,134 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/containers/podman/v2/pkg/api/handlers"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePodmanContainerExec() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePodmanContainerExecCreate,
+		ReadContext:   resourcePodmanContainerExecRead,
+		DeleteContext: resourcePodmanContainerExecDelete,
+
+		Schema: map[string]*schema.Schema{
+			"container": {
+				Type:        schema.TypeString,
+				Description: "Name or ID of the running container",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"command": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"user": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"working_dir": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"env": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"privileged": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"triggers": {
+				Type:        schema.TypeMap,
+				Description: "Arbitrary map of values that, when changed, re-run the command",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"fail_on_error": {
+				Type:        schema.TypeBool,
+				Description: "Whether a non-zero exit code fails the apply",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+
+			"stdout": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"stderr": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"exit_code": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePodmanContainerExecCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	container := d.Get("container").(string)
+	config := &handlers.ExecCreateConfig{}
+	config.Cmd = stringListToStringSlice(d.Get("command").([]interface{}))
+	config.User = d.Get("user").(string)
+	config.WorkingDir = d.Get("working_dir").(string)
+	config.Privileged = d.Get("privileged").(bool)
+	if v, ok := d.GetOk("env"); ok {
+		config.Env = stringSetToStringSlice(v.(*schema.Set))
+	}
+
+	result, err := podmanClient.ExecContainer(ctx, container, config)
+	if err != nil {
+		return diag.Errorf("Unable to exec in container %s: %s", container, err)
+	}
+
+	if result.ExitCode != 0 && d.Get("fail_on_error").(bool) {
+		return diag.Errorf("Command in container %s exited with code %d: %s", container, result.ExitCode, result.Stderr)
+	}
+
+	d.SetId(result.SessionId)
+	d.Set("stdout", result.Stdout)
+	d.Set("stderr", result.Stderr)
+	d.Set("exit_code", result.ExitCode)
+
+	return resourcePodmanContainerExecRead(ctx, d, meta)
+}
+
+func resourcePodmanContainerExecRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The exec session is a one-off, its results only live in the state
+	return nil
+}
+
+func resourcePodmanContainerExecDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
@@ -1 +1,129 @@
 package provider
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/containers/podman/v2/pkg/specgen"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestUploadToTar(t *testing.T) {
+	upload := map[string]interface{}{
+		"content":        "",
+		"content_base64": "c2VjcmV0Cg==",
+		"source":         "",
+		"file":           "/etc/app/secret",
+		"executable":     true,
+		"permissions":    "0600",
+	}
+
+	archive, err := uploadToTar(upload)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	tr := tar.NewReader(archive)
+	hdr, err := tr.Next()
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if hdr.Name != "/etc/app/secret" || hdr.Mode != 0600 {
+		t.Fatalf("unexpected header %s %o", hdr.Name, hdr.Mode)
+	}
+	content, err := ioutil.ReadAll(tr)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if string(content) != "secret\n" {
+		t.Fatalf("unexpected content %q", content)
+	}
+
+	upload["content"] = "plain"
+	if _, err := uploadToTar(upload); err == nil {
+		t.Fatalf("expected an error when both content and content_base64 are set")
+	}
+}
+
+func TestResourcePodmanContainerCreate(t *testing.T) {
+	var requests []string
+	record := func(handler http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			requests = append(requests, r.Method+" "+r.URL.Path)
+			handler(w, r)
+		}
+	}
+	var spec specgen.SpecGenerator
+	var uploaded string
+	podman := newFakePodman(t, "2.2.1", map[string]http.HandlerFunc{
+		"POST /images/pull": record(respondJSON(http.StatusOK, `{"images":["abc"],"id":"abc"}`)),
+		"POST /containers/create": record(func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&spec); err != nil {
+				t.Errorf("err: %s", err)
+			}
+			respondJSON(http.StatusCreated, `{"Id":"c1","Warnings":[]}`)(w, r)
+		}),
+		"PUT /containers/c1/archive": record(func(w http.ResponseWriter, r *http.Request) {
+			tr := tar.NewReader(r.Body)
+			hdr, err := tr.Next()
+			if err != nil {
+				t.Errorf("err: %s", err)
+				return
+			}
+			content, _ := ioutil.ReadAll(tr)
+			uploaded = hdr.Name + ":" + string(content)
+			w.WriteHeader(http.StatusOK)
+		}),
+		"POST /containers/c1/start": record(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}),
+		"POST /containers/c1/wait": record(respondJSON(http.StatusOK, `0`)),
+	})
+	defer podman.Close()
+
+	d := schema.TestResourceDataRaw(t, resourcePodmanContainer().Schema, map[string]interface{}{
+		"name":            "app",
+		"image":           "localhost/app:1.0",
+		"pod":             "web",
+		"max_retry_count": 3,
+		"rm":              true,
+		"read_only":       true,
+		"attach":          true,
+		"upload": []interface{}{
+			map[string]interface{}{
+				"file":    "/etc/app.conf",
+				"content": "debug=true",
+			},
+		},
+	})
+	if diags := resourcePodmanContainerCreate(context.Background(), d, &ProviderConfig{}); diags.HasError() {
+		t.Fatalf("err: %v", diags)
+	}
+
+	if d.Id() != "c1" {
+		t.Fatalf("expected id c1, got %q", d.Id())
+	}
+	if spec.Pod != "web" || !spec.Remove || !spec.ReadOnlyFilesystem {
+		t.Fatalf("unexpected spec %+v", spec)
+	}
+	if spec.RestartRetries == nil || *spec.RestartRetries != 3 {
+		t.Fatalf("expected 3 restart retries, got %v", spec.RestartRetries)
+	}
+	if uploaded != "/etc/app.conf:debug=true" {
+		t.Fatalf("unexpected upload %q", uploaded)
+	}
+
+	var order []string
+	for _, r := range requests {
+		order = append(order, r[strings.LastIndex(r, "/")+1:])
+	}
+	expected := []string{"pull", "create", "archive", "start", "wait", "wait"}
+	if strings.Join(order, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected requests %v, got %v", expected, requests)
+	}
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourcePodmanImageArchive() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePodmanImageArchiveCreate,
+		ReadContext:   resourcePodmanImageArchiveRead,
+		DeleteContext: resourcePodmanImageArchiveDelete,
+
+		Schema: map[string]*schema.Schema{
+			"images": {
+				Type:        schema.TypeList,
+				Description: "Names or IDs of the local images to save",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"path": {
+				Type:        schema.TypeString,
+				Description: "Path of the tar file to write",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"format": {
+				Type:             schema.TypeString,
+				Description:      "Archive format, docker-archive or oci-archive",
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "docker-archive",
+				ValidateDiagFunc: validateStringMatchesPattern(`^(docker-archive|oci-archive)$`),
+			},
+
+			"sha256": {
+				Type:        schema.TypeString,
+				Description: "SHA256 checksum of the archive",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourcePodmanImageArchiveCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	images := stringListToStringSlice(d.Get("images").([]interface{}))
+	format := d.Get("format").(string)
+	if len(images) > 1 && format != "docker-archive" {
+		return diag.Errorf("Saving multiple images is only supported with the docker-archive format")
+	}
+
+	path := d.Get("path").(string)
+	f, err := os.Create(path)
+	if err != nil {
+		return diag.Errorf("Unable to create archive %s: %s", path, err)
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if err := podmanClient.SaveImages(ctx, images, format, io.MultiWriter(f, hash)); err != nil {
+		os.Remove(path)
+		return diag.Errorf("Unable to save images to %s: %s", path, err)
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(path)
+		return diag.Errorf("Unable to write archive %s: %s", path, err)
+	}
+
+	d.SetId(path)
+	d.Set("sha256", hex.EncodeToString(hash.Sum(nil)))
+
+	return resourcePodmanImageArchiveRead(ctx, d, meta)
+}
+
+func resourcePodmanImageArchiveRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	hash, err := fileSha256Hex(d.Id())
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Printf("[WARN] Image archive (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Unable to read archive %s: %s", d.Id(), err)
+	}
+
+	// The archive is not reproducible, so a changed file has to be saved again
+	if hash != d.Get("sha256").(string) {
+		log.Printf("[WARN] Image archive (%s) was modified outside of Terraform, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func resourcePodmanImageArchiveDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := os.Remove(d.Id()); err != nil && !os.IsNotExist(err) {
+		return diag.Errorf("Unable to remove archive %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestResourcePodmanImageArchiveReadDrift(t *testing.T) {
+	dir, err := ioutil.TempDir("", "image-archive")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "images.tar")
+	if err := ioutil.WriteFile(path, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hash, err := fileSha256Hex(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourcePodmanImageArchive().Schema, map[string]interface{}{})
+	d.SetId(path)
+	d.Set("sha256", hash)
+	if diags := resourcePodmanImageArchiveRead(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("err: %v", diags)
+	}
+	if d.Id() != path {
+		t.Fatalf("unchanged archive was removed from state")
+	}
+
+	if err := ioutil.WriteFile(path, []byte("modified"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if diags := resourcePodmanImageArchiveRead(context.Background(), d, nil); diags.HasError() {
+		t.Fatalf("err: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Fatalf("modified archive was kept in state")
+	}
+}
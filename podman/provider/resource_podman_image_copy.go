@@ -0,0 +1,169 @@
+package provider
+
+import (
+	"context"
+	"time"
+
+	"github.com/containers/image/v5/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/saitho/terraform-provider-podman/podman/client"
+)
+
+var imageCopyAuthSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"username": {
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: true,
+		},
+		"password": {
+			Type:      schema.TypeString,
+			Required:  true,
+			ForceNew:  true,
+			Sensitive: true,
+		},
+	},
+}
+
+func resourcePodmanImageCopy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePodmanImageCopyCreate,
+		ReadContext:   resourcePodmanImageCopyRead,
+		DeleteContext: resourcePodmanImageCopyDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"source": {
+				Type:        schema.TypeString,
+				Description: "Source image including the transport, e.g. docker://docker.io/library/alpine:3.12",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"destination": {
+				Type:        schema.TypeString,
+				Description: "Destination image including the transport, e.g. oci:/srv/images/alpine:3.12",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"source_auth": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem:     imageCopyAuthSchema,
+			},
+
+			"destination_auth": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				MaxItems: 1,
+				Elem:     imageCopyAuthSchema,
+			},
+
+			"source_tls_verify": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"destination_tls_verify": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  true,
+			},
+
+			"signature_policy": {
+				Type:        schema.TypeString,
+				Description: "Path to a policy.json the source image must satisfy, all images are accepted if unset",
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"all": {
+				Type:        schema.TypeBool,
+				Description: "Copy all images of a manifest list instead of the one matching the host",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+
+			"preserve_digests": {
+				Type:        schema.TypeBool,
+				Description: "Copy the manifest unchanged and fail if its digest differs from the source",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+
+			"remove_signatures": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+
+			"digest": {
+				Type:        schema.TypeString,
+				Description: "Manifest digest of the copied image",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func imageCopyAuth(raw []interface{}) *types.DockerAuthConfig {
+	for _, rawAuth := range raw {
+		rawAuth := rawAuth.(map[string]interface{})
+		return &types.DockerAuthConfig{
+			Username: rawAuth["username"].(string),
+			Password: rawAuth["password"].(string),
+		}
+	}
+	return nil
+}
+
+func resourcePodmanImageCopyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	source := d.Get("source").(string)
+	destination := d.Get("destination").(string)
+	options := client.ImageCopyOptions{
+		SourceAuth:           imageCopyAuth(d.Get("source_auth").([]interface{})),
+		DestinationAuth:      imageCopyAuth(d.Get("destination_auth").([]interface{})),
+		SourceTLSVerify:      d.Get("source_tls_verify").(bool),
+		DestinationTLSVerify: d.Get("destination_tls_verify").(bool),
+		SignaturePolicyPath:  d.Get("signature_policy").(string),
+		All:                  d.Get("all").(bool),
+		PreserveDigests:      d.Get("preserve_digests").(bool),
+		RemoveSignatures:     d.Get("remove_signatures").(bool),
+	}
+
+	copiedDigest, err := client.CopyImage(ctx, source, destination, options)
+	if err != nil {
+		return diag.Errorf("Unable to copy %s to %s: %s", source, destination, err)
+	}
+
+	d.SetId(destination + "@" + copiedDigest.String())
+	d.Set("digest", copiedDigest.String())
+
+	return resourcePodmanImageCopyRead(ctx, d, meta)
+}
+
+func resourcePodmanImageCopyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Arbitrary transports cannot be inspected reliably, so the copy is tracked in the state only
+	return nil
+}
+
+func resourcePodmanImageCopyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Copied images are left at the destination
+	d.SetId("")
+	return nil
+}
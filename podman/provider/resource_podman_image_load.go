@@ -0,0 +1,161 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/saitho/terraform-provider-podman/podman/client"
+)
+
+func resourcePodmanImageLoad() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePodmanImageLoadCreate,
+		ReadContext:   resourcePodmanImageLoadRead,
+		UpdateContext: resourcePodmanImageLoadUpdate,
+		DeleteContext: resourcePodmanImageLoadDelete,
+
+		CustomizeDiff: resourcePodmanImageLoadCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Description: "Path of a docker-archive or oci-archive tar file",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"keep_locally": {
+				Type:        schema.TypeBool,
+				Description: "Keep the loaded images in local storage on destroy",
+				Optional:    true,
+				Default:     false,
+			},
+
+			"sha256": {
+				Type:        schema.TypeString,
+				Description: "SHA256 checksum of the loaded archive",
+				Computed:    true,
+			},
+
+			"images": {
+				Type:        schema.TypeList,
+				Description: "Names of the loaded images",
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func resourcePodmanImageLoadCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || d.HasChange("path") {
+		return nil
+	}
+
+	// Reload when the archive was replaced in place
+	hash, err := fileSha256Hex(d.Get("path").(string))
+	if err != nil {
+		// Archives are commonly removed once loaded, the loaded images are kept as they are then
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("Unable to read archive: %s", err)
+	}
+	if hash != d.Get("sha256").(string) {
+		if err := d.SetNew("sha256", hash); err != nil {
+			return err
+		}
+		return d.ForceNew("sha256")
+	}
+	return nil
+}
+
+func resourcePodmanImageLoadCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	path := d.Get("path").(string)
+	hash, err := fileSha256Hex(path)
+	if err != nil {
+		return diag.Errorf("Unable to read archive %s: %s", path, err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return diag.Errorf("Unable to read archive %s: %s", path, err)
+	}
+	defer f.Close()
+
+	images, err := podmanClient.LoadImages(ctx, f)
+	if err != nil {
+		return diag.Errorf("Unable to load archive %s: %s", path, err)
+	}
+
+	d.SetId(strings.Join(images, ","))
+	d.Set("sha256", hash)
+	d.Set("images", images)
+
+	return resourcePodmanImageLoadRead(ctx, d, meta)
+}
+
+func resourcePodmanImageLoadRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	var images []string
+	for _, image := range stringListToStringSlice(d.Get("images").([]interface{})) {
+		if _, err := podmanClient.InspectImage(ctx, image); err != nil {
+			if client.IsNotFound(err) {
+				continue
+			}
+			return diag.Errorf("Unable to inspect image %s: %s", image, err)
+		}
+		images = append(images, image)
+	}
+
+	if len(images) == 0 {
+		log.Printf("[WARN] Images loaded from (%s) not found, removing from state", d.Get("path").(string))
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("images", images)
+
+	return nil
+}
+
+func resourcePodmanImageLoadUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Only keep_locally can change in place, and it is only used on destroy
+	return resourcePodmanImageLoadRead(ctx, d, meta)
+}
+
+func resourcePodmanImageLoadDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if d.Get("keep_locally").(bool) {
+		d.SetId("")
+		return nil
+	}
+
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	for _, image := range stringListToStringSlice(d.Get("images").([]interface{})) {
+		if err := podmanClient.RemoveImage(ctx, image); err != nil && !client.IsNotFound(err) {
+			return diag.Errorf("Unable to remove image %s: %s", image, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourcePodmanImageLoadDiff(t *testing.T) {
+	dir, err := ioutil.TempDir("", "image-load")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "images.tar")
+
+	state := &terraform.InstanceState{
+		ID: "localhost/app:1.0",
+		Attributes: map[string]string{
+			"id":           "localhost/app:1.0",
+			"path":         path,
+			"keep_locally": "false",
+			"sha256":       "0000",
+			"images.#":     "1",
+			"images.0":     "localhost/app:1.0",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"path": path})
+
+	// A removed archive leaves the loaded images alone
+	diff, err := resourcePodmanImageLoad().Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no diff for a removed archive, got %v", diff)
+	}
+
+	// A replaced archive is loaded again
+	if err := ioutil.WriteFile(path, []byte("archive"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	diff, err = resourcePodmanImageLoad().Diff(context.Background(), state, config, nil)
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("expected a replaced archive to force a new resource, got %v", diff)
+	}
+}
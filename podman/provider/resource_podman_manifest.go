@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/containers/image/v5/types"
+	"github.com/containers/podman/v2/libpod/image"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/saitho/terraform-provider-podman/podman/client"
+)
+
+func resourcePodmanManifest() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePodmanManifestCreate,
+		ReadContext:   resourcePodmanManifestRead,
+		DeleteContext: resourcePodmanManifestDelete,
+
+		CustomizeDiff: resourcePodmanManifestCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Description: "Name of the manifest list, e.g. registry.example.com/app:1.0",
+				Required:    true,
+				ForceNew:    true,
+			},
+
+			"images": {
+				Type:     schema.TypeList,
+				Required: true,
+				ForceNew: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image": {
+							Type:        schema.TypeString,
+							Description: "Image reference including the transport, e.g. docker://registry.example.com/app:1.0-arm64",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"all": {
+							Type:        schema.TypeBool,
+							Description: "Add all images of the referenced manifest list",
+							Optional:    true,
+							ForceNew:    true,
+						},
+						"os": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"os_version": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"arch": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"variant": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+						"features": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+						"annotations": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"push": {
+				Type:        schema.TypeBool,
+				Description: "Whether to push the manifest list and its images",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+			},
+
+			"destination": {
+				Type:        schema.TypeString,
+				Description: "Push destination including the transport, defaults to docker://<name>",
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"digest": {
+				Type:        schema.TypeString,
+				Description: "Digest of the manifest list, as pushed to the destination if push is set",
+				Computed:    true,
+			},
+
+			"instance_digests": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// manifestDestination returns where a manifest list is pushed, its own name by default
+func manifestDestination(name, destination string) string {
+	if destination == "" {
+		return "docker://" + name
+	}
+	return destination
+}
+
+func resourcePodmanManifestCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.Get("push").(bool) || !d.NewValueKnown("name") || !d.NewValueKnown("destination") {
+		return nil
+	}
+	destination := manifestDestination(d.Get("name").(string), d.Get("destination").(string))
+	if meta.(*ProviderConfig).authForImage(strings.TrimPrefix(destination, "docker://")) != nil {
+		return requirePodmanFeature(ctx, meta, "push", client.FeatureManifestPushAuth)
+	}
+	return nil
+}
+
+func resourcePodmanManifestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	config := meta.(*ProviderConfig)
+	podmanClient := config.newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	name := d.Get("name").(string)
+	manifestId, err := podmanClient.CreateManifest(ctx, name)
+	if err != nil {
+		return diag.Errorf("Unable to create manifest list %s: %s", name, err)
+	}
+
+	d.SetId(manifestId)
+
+	for _, rawImage := range d.Get("images").([]interface{}) {
+		rawImage := rawImage.(map[string]interface{})
+		options := image.ManifestAddOpts{
+			Images:    []string{rawImage["image"].(string)},
+			All:       rawImage["all"].(bool),
+			OS:        rawImage["os"].(string),
+			OSVersion: rawImage["os_version"].(string),
+			Arch:      rawImage["arch"].(string),
+			Variant:   rawImage["variant"].(string),
+			Features:  stringListToStringSlice(rawImage["features"].([]interface{})),
+		}
+		if annotations := rawImage["annotations"].(map[string]interface{}); len(annotations) > 0 {
+			options.Annotation = mapTypeMapValsToString(annotations)
+		}
+		if err := podmanClient.AddToManifest(ctx, manifestId, options); err != nil {
+			return diag.Errorf("Unable to add %s to manifest list %s: %s", options.Images[0], name, err)
+		}
+	}
+
+	if d.Get("push").(bool) {
+		destination := manifestDestination(name, d.Get("destination").(string))
+		registryAuth := config.authForImage(strings.TrimPrefix(destination, "docker://"))
+		if err := podmanClient.PushManifest(ctx, manifestId, destination, registryAuth); err != nil {
+			return diag.Errorf("Unable to push manifest list %s to %s: %s", name, destination, err)
+		}
+
+		// Podman converts the list while pushing, so the digest has to come from the destination
+		listDigest, err := client.ImageDigest(ctx, destination, &types.SystemContext{DockerAuthConfig: registryAuth})
+		if err != nil {
+			return diag.Errorf("Unable to get digest of manifest list %s at %s: %s", name, destination, err)
+		}
+		d.Set("digest", listDigest.String())
+	} else {
+		listDigest, err := podmanClient.ManifestDigest(ctx, manifestId)
+		if err != nil {
+			return diag.Errorf("Unable to get digest of manifest list %s: %s", name, err)
+		}
+		d.Set("digest", listDigest.String())
+	}
+
+	return resourcePodmanManifestRead(ctx, d, meta)
+}
+
+func resourcePodmanManifestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	list, err := podmanClient.InspectManifest(ctx, d.Id())
+	if err != nil {
+		if client.IsNotFound(err) {
+			log.Printf("[WARN] Manifest list (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Unable to inspect manifest list %s: %s", d.Id(), err)
+	}
+
+	var instanceDigests []string
+	for _, instance := range list.Manifests {
+		instanceDigests = append(instanceDigests, instance.Digest.String())
+	}
+
+	d.Set("instance_digests", instanceDigests)
+
+	return nil
+}
+
+func resourcePodmanManifestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	if err := podmanClient.RemoveManifest(ctx, d.Id()); err != nil && !client.IsNotFound(err) {
+		return diag.Errorf("Unable to remove manifest list %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
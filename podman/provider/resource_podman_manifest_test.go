@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/containers/image/v5/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/opencontainers/go-digest"
+)
+
+const testManifestList = `{"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.list.v2+json","manifests":[{"mediaType":"application/vnd.docker.distribution.manifest.v2+json","size":528,"digest":"sha256:0123456789012345678901234567890123456789012345678901234567890123","platform":{"architecture":"arm64","os":"linux"}}]}`
+
+func testManifestRoutes(extra map[string]http.HandlerFunc) map[string]http.HandlerFunc {
+	routes := map[string]http.HandlerFunc{
+		"POST /manifests/create":   respondJSON(http.StatusOK, `{"Id":"list"}`),
+		"POST /manifests/list/add": respondJSON(http.StatusOK, `{"Id":"list"}`),
+		"GET /manifests/list/json": respondJSON(http.StatusOK, testManifestList),
+	}
+	for k, v := range extra {
+		routes[k] = v
+	}
+	return routes
+}
+
+func testManifestResourceData(t *testing.T, raw map[string]interface{}) *schema.ResourceData {
+	raw["name"] = "localhost/app:1.0"
+	raw["images"] = []interface{}{
+		map[string]interface{}{"image": "docker://localhost/app:1.0-arm64"},
+	}
+	return schema.TestResourceDataRaw(t, resourcePodmanManifest().Schema, raw)
+}
+
+func TestResourcePodmanManifestCreateLocalDigest(t *testing.T) {
+	podman := newFakePodman(t, "2.2.1", testManifestRoutes(nil))
+	defer podman.Close()
+
+	d := testManifestResourceData(t, map[string]interface{}{})
+	if diags := resourcePodmanManifestCreate(context.Background(), d, &ProviderConfig{}); diags.HasError() {
+		t.Fatalf("err: %v", diags)
+	}
+	if expected := digest.FromString(testManifestList).String(); d.Get("digest") != expected {
+		t.Fatalf("expected digest %s, got %s", expected, d.Get("digest"))
+	}
+}
+
+func TestResourcePodmanManifestCreatePushedDigest(t *testing.T) {
+	dest, err := ioutil.TempDir("", "manifest-push")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+	defer os.RemoveAll(dest)
+
+	// The pushed list differs from the local one, like after a format conversion
+	pushed := `{"schemaVersion":2,"manifests":[]}`
+	podman := newFakePodman(t, "2.2.1", testManifestRoutes(map[string]http.HandlerFunc{
+		"POST /manifests/list/push": func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("destination") != "dir:"+dest {
+				t.Errorf("unexpected destination %s", r.URL.Query().Get("destination"))
+			}
+			if err := ioutil.WriteFile(filepath.Join(dest, "manifest.json"), []byte(pushed), 0644); err != nil {
+				t.Errorf("err: %s", err)
+			}
+			respondJSON(http.StatusOK, `{"Id":"list"}`)(w, r)
+		},
+	}))
+	defer podman.Close()
+
+	d := testManifestResourceData(t, map[string]interface{}{
+		"push":        true,
+		"destination": "dir:" + dest,
+	})
+	if diags := resourcePodmanManifestCreate(context.Background(), d, &ProviderConfig{}); diags.HasError() {
+		t.Fatalf("err: %v", diags)
+	}
+	if expected := digest.FromString(pushed).String(); d.Get("digest") != expected {
+		t.Fatalf("expected digest %s, got %s", expected, d.Get("digest"))
+	}
+}
+
+func TestResourcePodmanManifestDiffPushAuth(t *testing.T) {
+	podman := newFakePodman(t, "2.2.1", nil)
+	defer podman.Close()
+
+	meta := &ProviderConfig{
+		RegistryAuth: map[string]types.DockerAuthConfig{
+			"registry.example.com": {Username: "user", Password: "secret"},
+		},
+	}
+	raw := map[string]interface{}{
+		"name": "registry.example.com/app:1.0",
+		"images": []interface{}{
+			map[string]interface{}{"image": "docker://registry.example.com/app:1.0-arm64"},
+		},
+		"push": true,
+	}
+
+	_, err := resourcePodmanManifest().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), meta)
+	if err == nil || !strings.Contains(err.Error(), "3.0.0") {
+		t.Fatalf("expected an error naming the minimum version, got %v", err)
+	}
+
+	// Pushing without credentials works with any version
+	raw["name"] = "localhost/app:1.0"
+	if _, err := resourcePodmanManifest().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), meta); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
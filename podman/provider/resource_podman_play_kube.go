@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"strings"
+
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/saitho/terraform-provider-podman/podman/client"
+)
+
+func resourcePodmanPlayKube() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePodmanPlayKubeCreate,
+		ReadContext:   resourcePodmanPlayKubeRead,
+		DeleteContext: resourcePodmanPlayKubeDelete,
+
+		CustomizeDiff: resourcePodmanPlayKubeCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"yaml": {
+				Type:         schema.TypeString,
+				Description:  "Inline Kubernetes YAML",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"yaml", "file"},
+			},
+
+			"file": {
+				Type:         schema.TypeString,
+				Description:  "Path to a file containing Kubernetes YAML",
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"yaml", "file"},
+			},
+
+			"network": {
+				Type:        schema.TypeString,
+				Description: "Name of the CNI network the pods join",
+				Optional:    true,
+				ForceNew:    true,
+			},
+
+			"content_sha256": {
+				Type:        schema.TypeString,
+				Description: "SHA256 checksum of the applied YAML",
+				Computed:    true,
+			},
+
+			"pod_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"container_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func playKubeContent(yaml, file string) ([]byte, error) {
+	if file != "" {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("could not read file: %s", err)
+		}
+		return content, nil
+	}
+	return []byte(yaml), nil
+}
+
+func resourcePodmanPlayKubeCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.HasChange("network") && d.Get("network").(string) != "" {
+		if err := requirePodmanFeature(ctx, meta, "network", client.FeaturePlayKubeNetwork); err != nil {
+			return err
+		}
+	}
+
+	if d.Id() == "" || !d.NewValueKnown("yaml") || !d.NewValueKnown("file") {
+		return nil
+	}
+
+	// Changes to the content of "file" are invisible to Terraform, so compare checksums
+	content, err := playKubeContent(d.Get("yaml").(string), d.Get("file").(string))
+	if err != nil {
+		return err
+	}
+	if hash := sha256Hex(content); hash != d.Get("content_sha256").(string) {
+		if err := d.SetNew("content_sha256", hash); err != nil {
+			return err
+		}
+		return d.ForceNew("content_sha256")
+	}
+	return nil
+}
+
+func resourcePodmanPlayKubeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	content, err := playKubeContent(d.Get("yaml").(string), d.Get("file").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	options := entities.PlayKubeOptions{
+		Network: d.Get("network").(string),
+	}
+	report, err := podmanClient.PlayKube(ctx, content, options)
+	if err != nil {
+		return diag.Errorf("Unable to play kube YAML: %s", err)
+	}
+
+	var podIds []string
+	for _, pod := range report.Pods {
+		podIds = append(podIds, pod.ID)
+		for _, logLine := range pod.Logs {
+			log.Printf("[DEBUG] play kube pod %s: %s", pod.ID, logLine)
+		}
+	}
+	if len(podIds) == 0 {
+		return diag.Errorf("Unable to play kube YAML: no pods were created")
+	}
+
+	d.SetId(strings.Join(podIds, ","))
+	d.Set("content_sha256", sha256Hex(content))
+	d.Set("pod_ids", podIds)
+
+	return resourcePodmanPlayKubeRead(ctx, d, meta)
+}
+
+func resourcePodmanPlayKubeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	podIds := stringListToStringSlice(d.Get("pod_ids").([]interface{}))
+	var missing []string
+	var containerIds []string
+	for _, podId := range podIds {
+		pod, err := podmanClient.InspectPod(ctx, podId)
+		if err != nil {
+			if client.IsNotFound(err) {
+				missing = append(missing, podId)
+				continue
+			}
+			return diag.Errorf("Unable to inspect pod %s: %s", podId, err)
+		}
+
+		for _, container := range pod.Containers {
+			if container.ID != pod.InfraContainerID {
+				containerIds = append(containerIds, container.ID)
+			}
+		}
+	}
+
+	if len(missing) == len(podIds) {
+		log.Printf("[WARN] Pods of play kube (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if len(missing) > 0 {
+		// Playing the YAML again requires removing the remaining pods first,
+		// so the cleared checksum makes CustomizeDiff plan a replacement
+		log.Printf("[WARN] Pods %v created by play kube not found, planning replacement", missing)
+		d.Set("content_sha256", "")
+	}
+
+	d.Set("container_ids", containerIds)
+
+	return nil
+}
+
+func resourcePodmanPlayKubeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	for _, podId := range stringListToStringSlice(d.Get("pod_ids").([]interface{})) {
+		if err := podmanClient.RemovePod(ctx, podId); err != nil && !client.IsNotFound(err) {
+			return diag.Errorf("Unable to remove pod %s: %s", podId, err)
+		}
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,381 @@
+package provider
+
+import (
+	"context"
+	"log"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/containers/podman/v2/libpod/define"
+	"github.com/containers/podman/v2/pkg/specgen"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/saitho/terraform-provider-podman/podman/client"
+)
+
+func resourcePodmanPod() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePodmanPodCreate,
+		ReadContext:   resourcePodmanPodRead,
+		DeleteContext: resourcePodmanPodDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+
+			"hostname": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+
+			"labels": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     labelSchema,
+			},
+
+			"infra": {
+				Type:        schema.TypeBool,
+				Description: "Whether to create an infra container for the pod",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     true,
+			},
+
+			"infra_image": {
+				Type:        schema.TypeString,
+				Description: "Image used for the infra container",
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+			},
+
+			"infra_command": {
+				Type:        schema.TypeList,
+				Description: "Command run by the infra container",
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"infra_container_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"share": {
+				Type:        schema.TypeSet,
+				Description: "Namespaces shared by the containers of the pod",
+				Optional:    true,
+				ForceNew:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type:             schema.TypeString,
+					ValidateDiagFunc: validateStringMatchesPattern(`^(cgroup|ipc|net|pid|uts|user|none)$`),
+				},
+				Set: schema.HashString,
+			},
+
+			"ports": {
+				Type:        schema.TypeList,
+				Description: "Ports published by the infra container",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        portSchema,
+			},
+
+			"network_mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"networks": {
+				Type:        schema.TypeSet,
+				Description: "CNI networks the pod joins",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"mac_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// Podman reports MAC addresses in lower case
+					return strings.EqualFold(old, new)
+				},
+			},
+
+			"dns": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"dns_opts": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"dns_search": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Set:      schema.HashString,
+			},
+
+			"host": {
+				Type:        schema.TypeSet,
+				Description: "Additional hosts entries in the form hostname:ip",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+			},
+
+			"cgroup_parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourcePodmanPodCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	config := specgen.NewPodSpecGenerator()
+	config.Name = d.Get("name").(string)
+	config.NoInfra = !d.Get("infra").(bool)
+
+	if v, ok := d.GetOk("hostname"); ok {
+		config.Hostname = v.(string)
+	}
+	if v, ok := d.GetOk("labels"); ok {
+		config.Labels = labelSetToMap(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("infra_image"); ok {
+		config.InfraImage = v.(string)
+	}
+	if v, ok := d.GetOk("infra_command"); ok {
+		config.InfraCommand = stringListToStringSlice(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("share"); ok {
+		config.SharedNamespaces = stringSetToStringSlice(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("ports"); ok {
+		config.PortMappings = portSetToPodmanPortMappings(v.([]interface{}))
+	}
+	if v, ok := d.GetOk("network_mode"); ok {
+		config.NetNS = specgen.Namespace{
+			NSMode: specgen.NamespaceMode(v.(string)),
+		}
+	}
+	if v, ok := d.GetOk("networks"); ok {
+		config.CNINetworks = stringSetToStringSlice(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("ip_address"); ok {
+		ip := net.ParseIP(v.(string))
+		if ip == nil {
+			return diag.Errorf("Invalid ip_address %q", v.(string))
+		}
+		config.StaticIP = &ip
+	}
+	if v, ok := d.GetOk("mac_address"); ok {
+		mac, err := net.ParseMAC(v.(string))
+		if err != nil {
+			return diag.Errorf("Invalid mac_address %q: %s", v.(string), err)
+		}
+		config.StaticMAC = &mac
+	}
+	if v, ok := d.GetOk("dns"); ok {
+		config.DNSServer = stringSetToDNSServers(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("dns_opts"); ok {
+		config.DNSOption = stringSetToStringSlice(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("dns_search"); ok {
+		config.DNSSearch = stringSetToStringSlice(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("host"); ok {
+		config.HostAdd = stringSetToStringSlice(v.(*schema.Set))
+	}
+	if v, ok := d.GetOk("cgroup_parent"); ok {
+		config.CgroupParent = v.(string)
+	}
+
+	podId, err := podmanClient.CreatePod(ctx, config)
+	if err != nil {
+		return diag.Errorf("Unable to create pod: %s", err)
+	}
+
+	d.SetId(podId)
+
+	return resourcePodmanPodRead(ctx, d, meta)
+}
+
+func resourcePodmanPodRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	pod, err := podmanClient.InspectPod(ctx, d.Id())
+	if err != nil {
+		if client.IsNotFound(err) {
+			log.Printf("[WARN] Pod (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Unable to inspect pod %s: %s", d.Id(), err)
+	}
+
+	d.Set("name", pod.Name)
+	d.Set("hostname", pod.Hostname)
+	d.Set("labels", mapToLabelSet(pod.Labels))
+	d.Set("infra", pod.CreateInfra)
+	d.Set("infra_container_id", pod.InfraContainerID)
+	d.Set("share", pod.SharedNamespaces)
+	d.Set("cgroup_parent", pod.CgroupParent)
+
+	if infra := pod.InfraConfig; infra != nil {
+		d.Set("ports", orderPortsLike(d.Get("ports").([]interface{}), flattenInspectPortBindings(infra.PortBindings)))
+		if infra.HostNetwork {
+			d.Set("network_mode", "host")
+		} else if d.Get("network_mode").(string) == "host" {
+			d.Set("network_mode", "")
+		}
+		d.Set("networks", infra.Networks)
+		ipAddress := ""
+		if infra.StaticIP != nil {
+			ipAddress = infra.StaticIP.String()
+		}
+		d.Set("ip_address", ipAddress)
+		d.Set("mac_address", infra.StaticMAC.String())
+		d.Set("dns", infra.DNSServer)
+		d.Set("dns_opts", infra.DNSOption)
+		d.Set("dns_search", infra.DNSSearch)
+		d.Set("host", infra.HostAdd)
+	}
+
+	// The infra image and command are only visible on the infra container itself
+	if pod.InfraContainerID != "" {
+		infraContainer, err := podmanClient.InspectContainer(ctx, pod.InfraContainerID)
+		if err != nil {
+			return diag.Errorf("Unable to inspect infra container %s: %s", pod.InfraContainerID, err)
+		}
+		d.Set("infra_image", infraContainer.ImageName)
+		if infraContainer.Config != nil {
+			d.Set("infra_command", infraContainer.Config.Cmd)
+		}
+	}
+
+	return nil
+}
+
+// flattenInspectPortBindings converts port bindings like {"80/tcp": [{HostPort: "8080"}]} to ports blocks
+func flattenInspectPortBindings(bindings map[string][]define.InspectHostPort) []interface{} {
+	keys := make([]string, 0, len(bindings))
+	for key := range bindings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	ports := make([]interface{}, 0, len(bindings))
+	for _, key := range keys {
+		parts := strings.SplitN(key, "/", 2)
+		internal, err := strconv.Atoi(parts[0])
+		if err != nil {
+			continue
+		}
+		protocol := "tcp"
+		if len(parts) == 2 {
+			protocol = parts[1]
+		}
+		for _, binding := range bindings[key] {
+			external, _ := strconv.Atoi(binding.HostPort)
+			ip := binding.HostIP
+			if ip == "" {
+				ip = "0.0.0.0"
+			}
+			ports = append(ports, map[string]interface{}{
+				"internal": internal,
+				"external": external,
+				"ip":       ip,
+				"protocol": protocol,
+			})
+		}
+	}
+	return ports
+}
+
+// orderPortsLike keeps the configured order of ports if the inspected ports are the same ones
+func orderPortsLike(configured, inspected []interface{}) []interface{} {
+	if len(configured) != len(inspected) {
+		return inspected
+	}
+	remaining := make([]interface{}, len(inspected))
+	copy(remaining, inspected)
+	for _, c := range configured {
+		found := false
+		for i, p := range remaining {
+			if p != nil && reflect.DeepEqual(c, p) {
+				remaining[i] = nil
+				found = true
+				break
+			}
+		}
+		if !found {
+			return inspected
+		}
+	}
+	return configured
+}
+
+func resourcePodmanPodDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	if err := podmanClient.RemovePod(ctx, d.Id()); err != nil && !client.IsNotFound(err) {
+		return diag.Errorf("Unable to remove pod %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/containers/podman/v2/libpod/define"
+)
+
+func TestFlattenInspectPortBindings(t *testing.T) {
+	ports := flattenInspectPortBindings(map[string][]define.InspectHostPort{
+		"53/udp": {{HostIP: "127.0.0.1", HostPort: "5353"}},
+		"80/tcp": {{HostPort: "8080"}},
+	})
+	expected := []interface{}{
+		map[string]interface{}{"internal": 53, "external": 5353, "ip": "127.0.0.1", "protocol": "udp"},
+		map[string]interface{}{"internal": 80, "external": 8080, "ip": "0.0.0.0", "protocol": "tcp"},
+	}
+	if !reflect.DeepEqual(ports, expected) {
+		t.Fatalf("unexpected ports %v", ports)
+	}
+
+	configured := []interface{}{expected[1], expected[0]}
+	if ordered := orderPortsLike(configured, ports); !reflect.DeepEqual(ordered, configured) {
+		t.Fatalf("expected the configured order to be kept, got %v", ordered)
+	}
+	if ordered := orderPortsLike(configured[:1], ports); !reflect.DeepEqual(ordered, ports) {
+		t.Fatalf("expected the inspected ports on a mismatch, got %v", ordered)
+	}
+}
@@ -0,0 +1,519 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// quadletPortSchema mirrors portSchema, but nothing reads the host port back from a unit file,
+// so external is a plain optional argument
+var quadletPortSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"internal": portSchema.Schema["internal"],
+
+		"external": {
+			Type:     schema.TypeInt,
+			Optional: true,
+			ForceNew: true,
+		},
+
+		"ip":       portSchema.Schema["ip"],
+		"protocol": portSchema.Schema["protocol"],
+	},
+}
+
+func resourcePodmanQuadlet() *schema.Resource {
+	return &schema.Resource{
+		Create: resourcePodmanQuadletCreate,
+		Read:   resourcePodmanQuadletRead,
+		Delete: resourcePodmanQuadletDelete,
+
+		CustomizeDiff: resourcePodmanQuadletCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:             schema.TypeString,
+				Description:      "Name of the unit file without extension",
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateStringMatchesPattern(`^[a-zA-Z0-9_.@-]+$`),
+			},
+
+			"directory": {
+				Type:        schema.TypeString,
+				Description: "Directory read by the Quadlet systemd generator",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "/etc/containers/systemd",
+			},
+
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+
+			"wanted_by": {
+				Type:        schema.TypeList,
+				Description: "Targets that want the unit, e.g. default.target",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"container": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: quadletKinds,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"image": {
+							Type:     schema.TypeString,
+							Required: true,
+							ForceNew: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"pod": {
+							Type:        schema.TypeString,
+							Description: "Name of the podman_quadlet pod unit the container joins",
+							Optional:    true,
+							ForceNew:    true,
+						},
+
+						"entrypoint": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"command": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"working_dir": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"user": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"env": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"labels": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem:     labelSchema,
+						},
+
+						"ports": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     quadletPortSchema,
+						},
+
+						"volumes": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"container_path": {
+										Type:     schema.TypeString,
+										Required: true,
+										ForceNew: true,
+									},
+
+									"host_path": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										ForceNew:         true,
+										ValidateDiagFunc: validateDockerContainerPath,
+									},
+
+									"volume_name": {
+										Type:     schema.TypeString,
+										Optional: true,
+										ForceNew: true,
+									},
+
+									"read_only": {
+										Type:     schema.TypeBool,
+										Optional: true,
+										ForceNew: true,
+									},
+								},
+							},
+						},
+
+						"network_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"dns": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"dns_opts": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"dns_search": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+							Set:      schema.HashString,
+						},
+
+						"capabilities": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"add": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+
+									"drop": {
+										Type:     schema.TypeSet,
+										Optional: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+										Set:      schema.HashString,
+									},
+								},
+							},
+						},
+
+						"healthcheck": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"test": {
+										Type:     schema.TypeList,
+										Required: true,
+										ForceNew: true,
+										Elem:     &schema.Schema{Type: schema.TypeString},
+									},
+									"interval": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										ForceNew:         true,
+										Default:          "0s",
+										ValidateDiagFunc: validateDurationGeq0(),
+									},
+									"timeout": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										ForceNew:         true,
+										Default:          "0s",
+										ValidateDiagFunc: validateDurationGeq0(),
+									},
+									"start_period": {
+										Type:             schema.TypeString,
+										Optional:         true,
+										ForceNew:         true,
+										Default:          "0s",
+										ValidateDiagFunc: validateDurationGeq0(),
+									},
+									"retries": {
+										Type:             schema.TypeInt,
+										Optional:         true,
+										ForceNew:         true,
+										Default:          0,
+										ValidateDiagFunc: validateIntegerGeqThan(0),
+									},
+								},
+							},
+						},
+
+						"log_driver": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"privileged": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"restart": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							ForceNew:         true,
+							Default:          "no",
+							ValidateDiagFunc: validateStringMatchesPattern(`^(no|on-failure|always|unless-stopped)$`),
+						},
+					},
+				},
+			},
+
+			"pod": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: quadletKinds,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"ports": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     quadletPortSchema,
+						},
+
+						"network_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"labels": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem:     labelSchema,
+						},
+					},
+				},
+			},
+
+			"network": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				ForceNew:     true,
+				MaxItems:     1,
+				ExactlyOneOf: quadletKinds,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"driver": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"subnets": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"gateways": {
+							Type:     schema.TypeList,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"ip_range": {
+							Type:     schema.TypeString,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"ipv6": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"internal": {
+							Type:     schema.TypeBool,
+							Optional: true,
+							ForceNew: true,
+						},
+
+						"labels": {
+							Type:     schema.TypeSet,
+							Optional: true,
+							ForceNew: true,
+							Elem:     labelSchema,
+						},
+
+						"options": {
+							Type:     schema.TypeMap,
+							Optional: true,
+							ForceNew: true,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+
+			"file_name": {
+				Type:        schema.TypeString,
+				Description: "Path of the rendered unit file",
+				Computed:    true,
+			},
+
+			"content": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"content_sha256": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// quadletGetter is implemented by both schema.ResourceData and schema.ResourceDiff
+type quadletGetter interface {
+	Get(key string) interface{}
+}
+
+func renderQuadletFromSchema(d quadletGetter) (string, string, error) {
+	for _, kind := range quadletKinds {
+		blocks := d.Get(kind).([]interface{})
+		if len(blocks) == 0 {
+			continue
+		}
+		raw, ok := blocks[0].(map[string]interface{})
+		if !ok {
+			return "", "", fmt.Errorf("%s block must not be empty", kind)
+		}
+
+		fileName := filepath.Join(d.Get("directory").(string), d.Get("name").(string)+"."+kind)
+		wantedBy := stringListToStringSlice(d.Get("wanted_by").([]interface{}))
+		content, err := renderQuadlet(kind, d.Get("description").(string), wantedBy, raw)
+		return fileName, content, err
+	}
+	return "", "", fmt.Errorf("one of %v must be set", quadletKinds)
+}
+
+func resourcePodmanQuadletCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	for _, kind := range quadletKinds {
+		if !d.NewValueKnown(kind) {
+			return nil
+		}
+	}
+
+	// Read stores the checksum of the file on disk, so edits made outside of Terraform show up here
+	_, content, err := renderQuadletFromSchema(d)
+	if err != nil {
+		return err
+	}
+	if hash := sha256Hex([]byte(content)); hash != d.Get("content_sha256").(string) {
+		if err := d.SetNew("content_sha256", hash); err != nil {
+			return err
+		}
+		return d.ForceNew("content_sha256")
+	}
+	return nil
+}
+
+func resourcePodmanQuadletCreate(d *schema.ResourceData, meta interface{}) error {
+	fileName, content, err := renderQuadletFromSchema(d)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(fileName), 0755); err != nil {
+		return fmt.Errorf("Unable to create quadlet directory: %s", err)
+	}
+	if err := ioutil.WriteFile(fileName, []byte(content), 0644); err != nil {
+		return fmt.Errorf("Unable to write quadlet file %s: %s", fileName, err)
+	}
+
+	d.SetId(fileName)
+
+	return resourcePodmanQuadletRead(d, meta)
+}
+
+func resourcePodmanQuadletRead(d *schema.ResourceData, meta interface{}) error {
+	content, err := ioutil.ReadFile(d.Id())
+	if err != nil {
+		if os.IsNotExist(err) {
+			log.Printf("[WARN] Quadlet file (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Unable to read quadlet file %s: %s", d.Id(), err)
+	}
+
+	d.Set("file_name", d.Id())
+	d.Set("content", string(content))
+	d.Set("content_sha256", sha256Hex(content))
+
+	return nil
+}
+
+func resourcePodmanQuadletDelete(d *schema.ResourceData, meta interface{}) error {
+	if err := os.Remove(d.Id()); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("Unable to remove quadlet file %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
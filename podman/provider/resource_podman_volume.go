@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"context"
+	"log"
+
+	"github.com/containers/podman/v2/pkg/domain/entities"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/saitho/terraform-provider-podman/podman/client"
+)
+
+func resourcePodmanVolume() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcePodmanVolumeCreate,
+		ReadContext:   resourcePodmanVolumeRead,
+		DeleteContext: resourcePodmanVolumeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+
+			"labels": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				ForceNew: true,
+				Elem:     labelSchema,
+			},
+
+			"driver": {
+				Type:        schema.TypeString,
+				Description: "Name of the volume driver",
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+
+			"driver_options": {
+				Type:        schema.TypeMap,
+				Description: "key/value map of driver specific options, e.g. type, device and o for the local driver",
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+
+			"mountpoint": {
+				Type:        schema.TypeString,
+				Description: "Path on the host where the volume is mounted",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourcePodmanVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	options := entities.VolumeCreateOptions{}
+	if v, ok := d.GetOk("name"); ok {
+		options.Name = v.(string)
+	}
+	if v, ok := d.GetOk("driver"); ok {
+		options.Driver = v.(string)
+	}
+	if v, ok := d.GetOk("driver_options"); ok {
+		options.Options = mapTypeMapValsToString(v.(map[string]interface{}))
+	}
+	if v, ok := d.GetOk("labels"); ok {
+		options.Label = labelSetToMap(v.(*schema.Set))
+	}
+
+	volume, err := podmanClient.CreateVolume(ctx, options)
+	if err != nil {
+		return diag.Errorf("Unable to create volume: %s", err)
+	}
+
+	d.SetId(volume.Name)
+
+	return resourcePodmanVolumeRead(ctx, d, meta)
+}
+
+func resourcePodmanVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	volume, err := podmanClient.InspectVolume(ctx, d.Id())
+	if err != nil {
+		if client.IsNotFound(err) {
+			log.Printf("[WARN] Volume (%s) not found, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("Unable to inspect volume %s: %s", d.Id(), err)
+	}
+
+	d.Set("name", volume.Name)
+	d.Set("driver", volume.Driver)
+	d.Set("labels", mapToLabelSet(volume.Labels))
+	d.Set("mountpoint", volume.Mountpoint)
+	if len(volume.Options) > 0 {
+		d.Set("driver_options", volume.Options)
+	}
+
+	return nil
+}
+
+func resourcePodmanVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	podmanClient := meta.(*ProviderConfig).newClient()
+	if err := podmanClient.ConnectContext(ctx); err != nil {
+		return connectionDiagnostics(err)
+	}
+
+	if err := podmanClient.RemoveVolume(ctx, d.Id()); err != nil && !client.IsNotFound(err) {
+		return diag.Errorf("Unable to remove volume %s: %s", d.Id(), err)
+	}
+
+	d.SetId("")
+	return nil
+}
@@ -74,6 +74,30 @@ func validateDurationGeq0() schema.SchemaValidateDiagFunc {
 	}
 }
 
+func validateDurationGt0() schema.SchemaValidateDiagFunc {
+	return func(v interface{}, k cty.Path) diag.Diagnostics {
+		value := v.(string)
+		dur, err := time.ParseDuration(value)
+		if err != nil {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("%q is not a valid duration", k),
+				Detail:        fmt.Sprintf("%q is not a valid duration", k),
+				AttributePath: nil,
+			}}
+		}
+		if dur <= 0 {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "duration must be positive",
+				Detail:        "duration must be positive",
+				AttributePath: nil,
+			}}
+		}
+		return nil
+	}
+}
+
 func validateIntegerGeqThan(threshold int) schema.SchemaValidateDiagFunc {
 	return func(v interface{}, k cty.Path) diag.Diagnostics {
 		value := v.(int)
@@ -88,3 +112,10 @@ func validateIntegerGeqThan(threshold int) schema.SchemaValidateDiagFunc {
 		return nil
 	}
 }
+
+func validateImageTag(v interface{}, k cty.Path) diag.Diagnostics {
+	if _, _, err := splitImageTag(v.(string)); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
@@ -53,3 +53,40 @@ func checkDiagnostics(t *testing.T, tn string, got, expected diag.Diagnostics) {
 		}
 	}
 }
+
+func TestValidateDurationGt0(t *testing.T) {
+	cases := map[string]struct {
+		Value         interface{}
+		ExpectedDiags diag.Diagnostics
+	}{
+		"30s": {
+			Value:         "30s",
+			ExpectedDiags: nil,
+		},
+		"0s": {
+			Value: "0s",
+			ExpectedDiags: diag.Diagnostics{
+				{
+					Severity: diag.Error,
+				},
+			},
+		},
+		"invalid": {
+			Value: "soon",
+			ExpectedDiags: diag.Diagnostics{
+				{
+					Severity: diag.Error,
+				},
+			},
+		},
+	}
+
+	fn := validateDurationGt0()
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			diags := fn(tc.Value, cty.Path{})
+
+			checkDiagnostics(t, tn, diags, tc.ExpectedDiags)
+		})
+	}
+}